@@ -35,6 +35,8 @@ type Client struct {
 	debug       bool
 	rateLimiter *rate.Limiter
 	config      Config
+	httpClient  *http.Client
+	baseURL     string
 }
 
 // NewClient creates a new OpenAI API client
@@ -54,7 +56,7 @@ func NewClient(ctx context.Context, config Config) (*Client, error) {
 
 	// Set the Assistants API version to v2
 	openaiConfig.BaseURL = strings.TrimSuffix(openaiConfig.BaseURL, "/")
-	openaiConfig.HTTPClient = &http.Client{
+	client.httpClient = &http.Client{
 		Transport: &headerTransport{
 			base: http.DefaultTransport,
 			headers: map[string]string{
@@ -62,7 +64,10 @@ func NewClient(ctx context.Context, config Config) (*Client, error) {
 			},
 		},
 	}
+	openaiConfig.HTTPClient = client.httpClient
 
+	// Keep the resolved base URL for endpoints the SDK does not cover
+	client.baseURL = openaiConfig.BaseURL
 	client.OpenAI = openai.NewClientWithConfig(openaiConfig)
 	return client, nil
 }
@@ -196,22 +201,82 @@ func (c *Client) calculateBackoff(attempt int) time.Duration {
 
 // CreateRunRequest is our internal run creation request type
 type CreateRunRequest struct {
-	ThreadID            string
-	AssistantID         string
-	Model               string
-	Instructions        string
-	Tools               []openai.AssistantTool
-	Metadata            map[string]interface{}
-	MaxPromptTokens     int
-	MaxCompletionTokens int
+	ThreadID               string
+	AssistantID            string
+	Model                  string
+	Instructions           string
+	AdditionalInstructions string
+	AdditionalMessages     []openai.ThreadMessage
+	Tools                  []openai.AssistantTool
+	Metadata               map[string]interface{}
+	MaxPromptTokens        int
+	MaxCompletionTokens    int
+	Temperature            *float32
+	TopP                   *float32
+	TruncationStrategy     *openai.ThreadTruncationStrategy
+	// ResponseFormat is either "auto" or a format type such as "text" or "json_object"
+	ResponseFormat string
 }
 
 // CreateRun creates a new run for a thread
 func (c *Client) CreateRun(ctx context.Context, req *CreateRunRequest) (*openai.Run, error) {
+	runRequest, err := buildRunRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := c.OpenAI.CreateRun(ctx, req.ThreadID, runRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error creating run: %v", err)
+	}
+	return &run, nil
+}
+
+// CreateThreadAndRunRequest creates a thread and starts a run on it in one request.
+// The ThreadID and AdditionalMessages of the embedded run request are ignored.
+type CreateThreadAndRunRequest struct {
+	CreateRunRequest
+	Thread openai.ThreadRequest
+}
+
+// CreateThreadAndRun creates a new thread and starts a run on it
+func (c *Client) CreateThreadAndRun(ctx context.Context, req *CreateThreadAndRunRequest) (*openai.Run, error) {
+	runRequest, err := buildRunRequest(&req.CreateRunRequest)
+	if err != nil {
+		return nil, err
+	}
+	runRequest.AdditionalMessages = nil
+
+	run, err := c.OpenAI.CreateThreadAndRun(ctx, openai.CreateThreadAndRunRequest{
+		RunRequest: runRequest,
+		Thread:     req.Thread,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating thread and run: %v", err)
+	}
+	return &run, nil
+}
+
+// buildRunRequest converts our internal run request into the SDK request type
+func buildRunRequest(req *CreateRunRequest) (openai.RunRequest, error) {
 	runRequest := openai.RunRequest{
-		AssistantID:  req.AssistantID,
-		Model:        req.Model,
-		Instructions: req.Instructions,
+		AssistantID:            req.AssistantID,
+		Model:                  req.Model,
+		Instructions:           req.Instructions,
+		AdditionalInstructions: req.AdditionalInstructions,
+		AdditionalMessages:     req.AdditionalMessages,
+		Temperature:            req.Temperature,
+		TopP:                   req.TopP,
+		TruncationStrategy:     req.TruncationStrategy,
+	}
+
+	// The API takes "auto" as a plain string and anything else as a format object
+	switch req.ResponseFormat {
+	case "":
+	case "auto":
+		runRequest.ResponseFormat = "auto"
+	default:
+		runRequest.ResponseFormat = openai.ReponseFormat{Type: req.ResponseFormat}
 	}
 
 	if req.Metadata != nil {
@@ -234,7 +299,7 @@ func (c *Client) CreateRun(ctx context.Context, req *CreateRunRequest) (*openai.
 		case openai.AssistantToolTypeCodeInterpreter, openai.AssistantToolTypeRetrieval, openai.AssistantToolTypeFunction:
 			toolType = openai.ToolTypeFunction
 		default:
-			return nil, fmt.Errorf("unsupported tool type: %s", tool.Type)
+			return runRequest, fmt.Errorf("unsupported tool type: %s", tool.Type)
 		}
 		tools = append(tools, openai.Tool{
 			Type: toolType,
@@ -242,11 +307,7 @@ func (c *Client) CreateRun(ctx context.Context, req *CreateRunRequest) (*openai.
 	}
 	runRequest.Tools = tools
 
-	run, err := c.OpenAI.CreateRun(ctx, req.ThreadID, runRequest)
-	if err != nil {
-		return nil, fmt.Errorf("error creating run: %v", err)
-	}
-	return &run, nil
+	return runRequest, nil
 }
 
 // GetRun retrieves a run by ID and thread ID
@@ -271,7 +332,7 @@ func (c *Client) CancelRun(ctx context.Context, id string, threadID string) erro
 
 	// If the run is already in a terminal state, just return
 	switch run.Status {
-	case openai.RunStatusCompleted, openai.RunStatusFailed, openai.RunStatusCancelled, openai.RunStatusExpired:
+	case openai.RunStatusCompleted, openai.RunStatusIncomplete, openai.RunStatusFailed, openai.RunStatusCancelled, openai.RunStatusExpired:
 		return nil
 	}
 
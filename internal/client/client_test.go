@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// newTestClient returns a client that sends every request to handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(context.Background(), Config{APIKey: "test-key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+	return c
+}
+
+// fakeList serves a list endpoint that pages through ids with the after and
+// limit cursors, the way every list endpoint of the API does
+type fakeList struct {
+	t    *testing.T
+	path string
+	ids  []string
+	// omitLastID leaves last_id out of each page, as some endpoints do
+	omitLastID bool
+
+	mu      sync.Mutex
+	queries []url.Values
+}
+
+func (f *fakeList) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Method != http.MethodGet || r.URL.Path != f.path {
+		f.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	query := r.URL.Query()
+	f.queries = append(f.queries, query)
+
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		f.t.Errorf("got limit %q, want a positive number", query.Get("limit"))
+		limit = len(f.ids)
+	}
+
+	start := 0
+	if after := query.Get("after"); after != "" {
+		start = -1
+		for i, id := range f.ids {
+			if id == after {
+				start = i + 1
+			}
+		}
+		if start < 0 {
+			f.t.Errorf("got unknown cursor %q", after)
+			start = len(f.ids)
+		}
+	}
+	end := start + limit
+	if end > len(f.ids) {
+		end = len(f.ids)
+	}
+
+	data := []map[string]string{}
+	for _, id := range f.ids[start:end] {
+		data = append(data, map[string]string{"id": id})
+	}
+	page := map[string]interface{}{
+		"object":   "list",
+		"data":     data,
+		"has_more": end < len(f.ids),
+	}
+	if !f.omitLastID && end > start {
+		page["last_id"] = f.ids[end-1]
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(page); err != nil {
+		f.t.Errorf("encoding page: %s", err)
+	}
+}
+
+// testIDs returns n IDs with the given prefix
+func testIDs(prefix string, n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = prefix + strconv.Itoa(i+1)
+	}
+	return ids
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// filesPageSize is the page size used when listing files
+const filesPageSize = 1000
+
+// File is an uploaded file including its expiry, which the go-openai File
+// type does not decode
+type File struct {
+	openai.File
+	ExpiresAt *int64 `json:"expires_at"`
+}
+
+// FileExpiresAfter is an expiration policy for an uploaded file
+type FileExpiresAfter struct {
+	Anchor  string `json:"anchor"`
+	Seconds int64  `json:"seconds"`
+}
+
+// CreateFileRequest describes a file uploaded in a single request
+type CreateFileRequest struct {
+	Filename     string
+	Purpose      string
+	Content      io.Reader
+	ExpiresAfter *FileExpiresAfter
+}
+
+// CreateFile uploads a file in a single multipart request. Unlike the go-openai
+// CreateFile it accepts any reader and an expiration policy. The request body
+// is streamed from the reader, so the file is never held in memory.
+func (c *Client) CreateFile(ctx context.Context, req CreateFileRequest) (*File, error) {
+	body, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+
+	// The form is written while the request is sent. A failure while writing
+	// fails the request, and a request that ends early closes the pipe.
+	go func() {
+		pw.CloseWithError(writeFileForm(form, req))
+	}()
+
+	httpReq, err := c.newRawRequest(ctx, http.MethodPost, "/files", form.FormDataContentType(), body)
+	if err != nil {
+		body.CloseWithError(err)
+		return nil, err
+	}
+
+	var file File
+	if err := c.do(httpReq, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// writeFileForm writes the fields and content of a file upload to form
+func writeFileForm(form *multipart.Writer, req CreateFileRequest) error {
+	fields := map[string]string{"purpose": req.Purpose}
+	if req.ExpiresAfter != nil {
+		fields["expires_after[anchor]"] = req.ExpiresAfter.Anchor
+		fields["expires_after[seconds]"] = strconv.FormatInt(req.ExpiresAfter.Seconds, 10)
+	}
+	for name, value := range fields {
+		if err := form.WriteField(name, value); err != nil {
+			return fmt.Errorf("error building file upload: %w", err)
+		}
+	}
+
+	w, err := form.CreateFormFile("file", req.Filename)
+	if err != nil {
+		return fmt.Errorf("error building file upload: %w", err)
+	}
+	if _, err := io.Copy(w, req.Content); err != nil {
+		return fmt.Errorf("error reading file: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return fmt.Errorf("error building file upload: %w", err)
+	}
+	return nil
+}
+
+// GetFile retrieves an uploaded file. Errors are returned as *openai.APIError
+// so callers can check the status code.
+func (c *Client) GetFile(ctx context.Context, fileID string) (*File, error) {
+	var file File
+	if err := c.doJSON(ctx, http.MethodGet, "/files/"+fileID, nil, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// DownloadFile streams the content of an uploaded file to w through the files
+// content endpoint and returns the number of bytes written
+func (c *Client) DownloadFile(ctx context.Context, fileID string, w io.Writer) (int64, error) {
+	content, err := c.OpenAI.GetFileContent(ctx, fileID)
+	if err != nil {
+		return 0, fmt.Errorf("error downloading file %s: %w", fileID, err)
+	}
+	defer content.Close()
+
+	n, err := io.Copy(w, content)
+	if err != nil {
+		return n, fmt.Errorf("error reading content of file %s: %w", fileID, err)
+	}
+	return n, nil
+}
+
+// ListFilesOptions filters and orders the files returned by ListFiles
+type ListFilesOptions struct {
+	// Purpose restricts the results to files uploaded for one purpose
+	Purpose string
+	// Order is "asc" or "desc" by creation time. Defaults to "desc".
+	Order string
+}
+
+// fileList is a page of files
+type fileList struct {
+	Data    []File `json:"data"`
+	LastID  string `json:"last_id"`
+	HasMore bool   `json:"has_more"`
+}
+
+// ListFiles returns every file matching the options, following pagination
+// cursors until the API reports no more results
+func (c *Client) ListFiles(ctx context.Context, opts ListFilesOptions) ([]File, error) {
+	order := opts.Order
+	if order == "" {
+		order = "desc"
+	}
+
+	var files []File
+	after := ""
+
+	for {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(filesPageSize))
+		query.Set("order", order)
+		if opts.Purpose != "" {
+			query.Set("purpose", opts.Purpose)
+		}
+		if after != "" {
+			query.Set("after", after)
+		}
+
+		var page fileList
+		if err := c.doJSON(ctx, http.MethodGet, "/files?"+query.Encode(), nil, &page); err != nil {
+			return nil, fmt.Errorf("error listing files: %w", err)
+		}
+
+		files = append(files, page.Data...)
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		after = page.LastID
+		if after == "" {
+			after = page.Data[len(page.Data)-1].ID
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestListFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		count      int
+		omitLastID bool
+		opts       ListFilesOptions
+		wantPages  int
+		wantOrder  string
+	}{
+		{
+			name:      "empty",
+			wantPages: 1,
+			wantOrder: "desc",
+		},
+		{
+			name:      "single page",
+			count:     filesPageSize,
+			wantPages: 1,
+			wantOrder: "desc",
+		},
+		{
+			name:      "several pages",
+			count:     filesPageSize*2 + 1,
+			opts:      ListFilesOptions{Purpose: "assistants", Order: "asc"},
+			wantPages: 3,
+			wantOrder: "asc",
+		},
+		{
+			name:       "pages without last_id",
+			count:      filesPageSize + 1,
+			omitLastID: true,
+			wantPages:  2,
+			wantOrder:  "desc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeList{t: t, path: "/files", ids: testIDs("file-", tt.count), omitLastID: tt.omitLastID}
+			c := newTestClient(t, fake.ServeHTTP)
+
+			files, err := c.ListFiles(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var got []string
+			for _, file := range files {
+				got = append(got, file.ID)
+			}
+			if len(got) != tt.count || (tt.count > 0 && !reflect.DeepEqual(got, fake.ids)) {
+				t.Errorf("got %d files, want %d in order", len(got), tt.count)
+			}
+			if len(fake.queries) != tt.wantPages {
+				t.Errorf("got %d requests, want %d", len(fake.queries), tt.wantPages)
+			}
+			for _, query := range fake.queries {
+				if query.Get("order") != tt.wantOrder || query.Get("purpose") != tt.opts.Purpose {
+					t.Errorf("got query %v, want order %q and purpose %q", query, tt.wantOrder, tt.opts.Purpose)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// messagesPageSize is the largest page size accepted by the messages endpoint
+const messagesPageSize = 100
+
+// Message is a thread message with typed content parts, annotations and
+// attachments, which the go-openai Message type leaves undecoded
+type Message struct {
+	ID          string                    `json:"id"`
+	Object      string                    `json:"object"`
+	CreatedAt   int64                     `json:"created_at"`
+	ThreadID    string                    `json:"thread_id"`
+	Role        string                    `json:"role"`
+	Content     []MessageContent          `json:"content"`
+	AssistantID *string                   `json:"assistant_id,omitempty"`
+	RunID       *string                   `json:"run_id,omitempty"`
+	Attachments []openai.ThreadAttachment `json:"attachments,omitempty"`
+	Metadata    map[string]interface{}    `json:"metadata,omitempty"`
+}
+
+// MessageContent is a single content part of a message
+type MessageContent struct {
+	Type      string            `json:"type"`
+	Text      *MessageText      `json:"text,omitempty"`
+	ImageFile *MessageImageFile `json:"image_file,omitempty"`
+	ImageURL  *MessageImageURL  `json:"image_url,omitempty"`
+}
+
+// MessageText is the text of a content part and the annotations within it
+type MessageText struct {
+	Value       string              `json:"value"`
+	Annotations []MessageAnnotation `json:"annotations"`
+}
+
+// MessageImageFile references an uploaded image file
+type MessageImageFile struct {
+	FileID string `json:"file_id"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// MessageImageURL references an external image
+type MessageImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// MessageAnnotation is a file citation or file path annotation within message text
+type MessageAnnotation struct {
+	Type         string `json:"type"`
+	Text         string `json:"text"`
+	StartIndex   int    `json:"start_index"`
+	EndIndex     int    `json:"end_index"`
+	FileCitation *struct {
+		FileID string `json:"file_id"`
+		Quote  string `json:"quote,omitempty"`
+	} `json:"file_citation,omitempty"`
+	FilePath *struct {
+		FileID string `json:"file_id"`
+	} `json:"file_path,omitempty"`
+}
+
+// FileID returns the ID of the file an annotation refers to
+func (a MessageAnnotation) FileID() string {
+	switch {
+	case a.FileCitation != nil:
+		return a.FileCitation.FileID
+	case a.FilePath != nil:
+		return a.FilePath.FileID
+	}
+	return ""
+}
+
+// ListMessagesOptions filters the messages returned by ListMessages
+type ListMessagesOptions struct {
+	// Order is "asc" or "desc" by creation time. Defaults to "asc".
+	Order string
+	// RunID restricts the results to messages created by a single run
+	RunID string
+	// Limit caps the number of messages returned. Zero returns every message.
+	Limit int
+}
+
+// messageList is a page of messages
+type messageList struct {
+	Data    []Message `json:"data"`
+	LastID  string    `json:"last_id"`
+	HasMore bool      `json:"has_more"`
+}
+
+// ListMessages returns the messages in a thread matching the options,
+// following pagination cursors until the API reports no more results or the
+// limit is reached
+func (c *Client) ListMessages(ctx context.Context, threadID string, opts ListMessagesOptions) ([]Message, error) {
+	order := opts.Order
+	if order == "" {
+		order = "asc"
+	}
+
+	var messages []Message
+	after := ""
+
+	for {
+		pageSize := messagesPageSize
+		if opts.Limit > 0 && opts.Limit-len(messages) < pageSize {
+			pageSize = opts.Limit - len(messages)
+		}
+
+		query := url.Values{}
+		query.Set("limit", fmt.Sprintf("%d", pageSize))
+		query.Set("order", order)
+		if opts.RunID != "" {
+			query.Set("run_id", opts.RunID)
+		}
+		if after != "" {
+			query.Set("after", after)
+		}
+
+		var page messageList
+		path := fmt.Sprintf("/threads/%s/messages?%s", threadID, query.Encode())
+		if err := c.doJSON(ctx, http.MethodGet, path, nil, &page); err != nil {
+			return nil, fmt.Errorf("error listing messages: %w", err)
+		}
+
+		messages = append(messages, page.Data...)
+		if opts.Limit > 0 && len(messages) >= opts.Limit {
+			messages = messages[:opts.Limit]
+			break
+		}
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		after = page.LastID
+		if after == "" {
+			after = page.Data[len(page.Data)-1].ID
+		}
+	}
+
+	return messages, nil
+}
+
+// MessageContentPart is a content part of a message being created
+type MessageContentPart struct {
+	Type      string            `json:"type"`
+	Text      string            `json:"text,omitempty"`
+	ImageURL  *MessageImageURL  `json:"image_url,omitempty"`
+	ImageFile *MessageImageFile `json:"image_file,omitempty"`
+}
+
+// CreateMessageRequest creates a message whose content is either a string or
+// a list of content parts, which the go-openai MessageRequest cannot express
+type CreateMessageRequest struct {
+	Role        string                    `json:"role"`
+	Content     interface{}               `json:"content"`
+	Attachments []openai.ThreadAttachment `json:"attachments,omitempty"`
+	Metadata    map[string]interface{}    `json:"metadata,omitempty"`
+}
+
+// CreateMessage adds a message to a thread. Errors are returned as
+// *openai.APIError so callers can check the status code.
+func (c *Client) CreateMessage(ctx context.Context, threadID string, req CreateMessageRequest) (*Message, error) {
+	var message Message
+	if err := c.doJSON(ctx, http.MethodPost, fmt.Sprintf("/threads/%s/messages", threadID), req, &message); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// GetMessage retrieves a single message with all of its content parts. Errors
+// are returned as *openai.APIError so callers can check the status code.
+func (c *Client) GetMessage(ctx context.Context, threadID string, messageID string) (*Message, error) {
+	var message Message
+	if err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf("/threads/%s/messages/%s", threadID, messageID), nil, &message); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestListMessages(t *testing.T) {
+	tests := []struct {
+		name       string
+		count      int
+		omitLastID bool
+		opts       ListMessagesOptions
+		wantCount  int
+		wantLimits []string
+		wantOrder  string
+	}{
+		{
+			name:       "empty",
+			wantLimits: []string{"100"},
+			wantOrder:  "asc",
+		},
+		{
+			name:       "several pages",
+			count:      messagesPageSize*2 + 5,
+			wantCount:  messagesPageSize*2 + 5,
+			wantLimits: []string{"100", "100", "100"},
+			wantOrder:  "asc",
+		},
+		{
+			name:       "pages without last_id",
+			count:      messagesPageSize + 1,
+			omitLastID: true,
+			wantCount:  messagesPageSize + 1,
+			wantLimits: []string{"100", "100"},
+			wantOrder:  "asc",
+		},
+		{
+			name:       "newest first for one run",
+			count:      3,
+			opts:       ListMessagesOptions{Order: "desc", RunID: "run_1"},
+			wantCount:  3,
+			wantLimits: []string{"100"},
+			wantOrder:  "desc",
+		},
+		{
+			name:       "limit within a page",
+			count:      messagesPageSize,
+			opts:       ListMessagesOptions{Limit: 1},
+			wantCount:  1,
+			wantLimits: []string{"1"},
+			wantOrder:  "asc",
+		},
+		{
+			name:       "limit across pages",
+			count:      messagesPageSize * 2,
+			opts:       ListMessagesOptions{Limit: messagesPageSize + 20},
+			wantCount:  messagesPageSize + 20,
+			wantLimits: []string{"100", "20"},
+			wantOrder:  "asc",
+		},
+		{
+			name:       "limit above the total",
+			count:      5,
+			opts:       ListMessagesOptions{Limit: 10},
+			wantCount:  5,
+			wantLimits: []string{"10"},
+			wantOrder:  "asc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeList{t: t, path: "/threads/thread_1/messages", ids: testIDs("msg_", tt.count), omitLastID: tt.omitLastID}
+			c := newTestClient(t, fake.ServeHTTP)
+
+			messages, err := c.ListMessages(context.Background(), "thread_1", tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var got []string
+			for _, msg := range messages {
+				got = append(got, msg.ID)
+			}
+			if len(got) != tt.wantCount || (tt.wantCount > 0 && !reflect.DeepEqual(got, fake.ids[:tt.wantCount])) {
+				t.Errorf("got %d messages, want the first %d in order", len(got), tt.wantCount)
+			}
+
+			var limits []string
+			for _, query := range fake.queries {
+				limits = append(limits, query.Get("limit"))
+				if query.Get("order") != tt.wantOrder || query.Get("run_id") != tt.opts.RunID {
+					t.Errorf("got query %v, want order %q and run_id %q", query, tt.wantOrder, tt.opts.RunID)
+				}
+			}
+			if !reflect.DeepEqual(limits, tt.wantLimits) {
+				t.Errorf("got page limits %v, want %v", limits, tt.wantLimits)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// newRequest builds an authenticated request against the OpenAI API for
+// endpoints that the go-openai SDK does not expose
+func (c *Client) newRequest(ctx context.Context, method string, path string, body interface{}) (*http.Request, error) {
+	if body == nil {
+		return c.newRawRequest(ctx, method, path, "", nil)
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding request body: %v", err)
+	}
+	return c.newRawRequest(ctx, method, path, "application/json", bytes.NewReader(payload))
+}
+
+// newRawRequest builds an authenticated request with a pre-encoded body, such
+// as a multipart form
+func (c *Client) newRawRequest(ctx context.Context, method string, path string, contentType string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %v", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	if c.config.Organization != "" {
+		req.Header.Set("OpenAI-Organization", c.config.Organization)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req, nil
+}
+
+// errorFromResponse converts a failed HTTP response into an *openai.APIError so
+// callers can use the same status code checks as for SDK errors
+func errorFromResponse(resp *http.Response) error {
+	apiErr := &openai.APIError{
+		HTTPStatus:     resp.Status,
+		HTTPStatusCode: resp.StatusCode,
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err == nil {
+		var errResp openai.ErrorResponse
+		if json.Unmarshal(data, &errResp) == nil && errResp.Error != nil {
+			errResp.Error.HTTPStatus = resp.Status
+			errResp.Error.HTTPStatusCode = resp.StatusCode
+			return errResp.Error
+		}
+		apiErr.Message = string(data)
+	}
+	return apiErr
+}
+
+// doJSON sends a request built by newRequest and decodes the JSON response into out
+func (c *Client) doJSON(ctx context.Context, method string, path string, body interface{}, out interface{}) error {
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	return c.do(req, out)
+}
+
+// do sends req and decodes the JSON response into out
+func (c *Client) do(req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return errorFromResponse(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding response: %v", err)
+	}
+	return nil
+}
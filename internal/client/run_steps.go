@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// runStepsPageSize is the largest page size accepted by the run steps endpoint
+const runStepsPageSize = 100
+
+// RunStep is a run step including the tool call details and usage that the
+// go-openai RunStep type does not decode
+type RunStep struct {
+	ID          string               `json:"id"`
+	CreatedAt   int64                `json:"created_at"`
+	RunID       string               `json:"run_id"`
+	Type        string               `json:"type"`
+	Status      string               `json:"status"`
+	StepDetails RunStepDetails       `json:"step_details"`
+	LastError   *openai.RunLastError `json:"last_error,omitempty"`
+	CompletedAt *int64               `json:"completed_at,omitempty"`
+	Usage       *RunUsage            `json:"usage,omitempty"`
+}
+
+// RunUsage holds token usage for a run or run step
+type RunUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// RunStepDetails describes what a run step did
+type RunStepDetails struct {
+	Type            string `json:"type"`
+	MessageCreation *struct {
+		MessageID string `json:"message_id"`
+	} `json:"message_creation,omitempty"`
+	ToolCalls []RunStepToolCall `json:"tool_calls,omitempty"`
+}
+
+// RunStepToolCall is a single tool call made during a run step
+type RunStepToolCall struct {
+	ID              string                      `json:"id"`
+	Type            string                      `json:"type"`
+	CodeInterpreter *RunStepCodeInterpreterCall `json:"code_interpreter,omitempty"`
+	FileSearch      *RunStepFileSearchCall      `json:"file_search,omitempty"`
+	Function        *RunStepFunctionCall        `json:"function,omitempty"`
+}
+
+// RunStepCodeInterpreterCall holds the input and outputs of a code interpreter call
+type RunStepCodeInterpreterCall struct {
+	Input   string `json:"input"`
+	Outputs []struct {
+		Type  string `json:"type"`
+		Logs  string `json:"logs,omitempty"`
+		Image *struct {
+			FileID string `json:"file_id"`
+		} `json:"image,omitempty"`
+	} `json:"outputs"`
+}
+
+// RunStepFileSearchCall holds the results of a file search call
+type RunStepFileSearchCall struct {
+	Results []struct {
+		FileID   string  `json:"file_id"`
+		FileName string  `json:"file_name"`
+		Score    float64 `json:"score"`
+	} `json:"results,omitempty"`
+}
+
+// RunStepFunctionCall holds the name, arguments and output of a function call
+type RunStepFunctionCall struct {
+	Name      string  `json:"name"`
+	Arguments string  `json:"arguments"`
+	Output    *string `json:"output,omitempty"`
+}
+
+// runStepList is a page of run steps
+type runStepList struct {
+	Data    []RunStep `json:"data"`
+	LastID  string    `json:"last_id"`
+	HasMore bool      `json:"has_more"`
+}
+
+// ListRunSteps returns every step of a run in creation order, following
+// pagination cursors until the API reports no more results
+func (c *Client) ListRunSteps(ctx context.Context, threadID string, runID string) ([]RunStep, error) {
+	var steps []RunStep
+	after := ""
+
+	for {
+		query := url.Values{}
+		query.Set("limit", fmt.Sprintf("%d", runStepsPageSize))
+		query.Set("order", "asc")
+		if after != "" {
+			query.Set("after", after)
+		}
+
+		var page runStepList
+		path := fmt.Sprintf("/threads/%s/runs/%s/steps?%s", threadID, runID, query.Encode())
+		if err := c.doJSON(ctx, http.MethodGet, path, nil, &page); err != nil {
+			return nil, fmt.Errorf("error listing run steps: %w", err)
+		}
+
+		steps = append(steps, page.Data...)
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		after = page.LastID
+		if after == "" {
+			after = page.Data[len(page.Data)-1].ID
+		}
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestListRunSteps(t *testing.T) {
+	tests := []struct {
+		name       string
+		count      int
+		omitLastID bool
+		wantPages  int
+	}{
+		{
+			name:      "empty",
+			wantPages: 1,
+		},
+		{
+			name:      "single page",
+			count:     runStepsPageSize,
+			wantPages: 1,
+		},
+		{
+			name:      "several pages",
+			count:     runStepsPageSize + 1,
+			wantPages: 2,
+		},
+		{
+			name:       "pages without last_id",
+			count:      runStepsPageSize*2 + 1,
+			omitLastID: true,
+			wantPages:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeList{t: t, path: "/threads/thread_1/runs/run_1/steps", ids: testIDs("step_", tt.count), omitLastID: tt.omitLastID}
+			c := newTestClient(t, fake.ServeHTTP)
+
+			steps, err := c.ListRunSteps(context.Background(), "thread_1", "run_1")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var got []string
+			for _, step := range steps {
+				got = append(got, step.ID)
+			}
+			if len(got) != tt.count || (tt.count > 0 && !reflect.DeepEqual(got, fake.ids)) {
+				t.Errorf("got %d steps, want %d in order", len(got), tt.count)
+			}
+			if len(fake.queries) != tt.wantPages {
+				t.Errorf("got %d requests, want %d", len(fake.queries), tt.wantPages)
+			}
+			for _, query := range fake.queries {
+				if query.Get("order") != "asc" {
+					t.Errorf("got order %q, want asc", query.Get("order"))
+				}
+			}
+		})
+	}
+}
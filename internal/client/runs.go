@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// runsPageSize is the largest page size accepted by the runs endpoint
+const runsPageSize = 100
+
+// RunSettings holds the sampling and response format options a run was
+// created with, which the go-openai Run type does not decode
+type RunSettings struct {
+	Temperature    *float64        `json:"temperature"`
+	TopP           *float64        `json:"top_p"`
+	ResponseFormat json.RawMessage `json:"response_format"`
+}
+
+// ResponseFormatType returns the type of the run's response format. The API
+// reports the default as the string "auto" and any other format as an object.
+func (s *RunSettings) ResponseFormatType() string {
+	if len(s.ResponseFormat) == 0 || string(s.ResponseFormat) == "null" {
+		return ""
+	}
+
+	var name string
+	if json.Unmarshal(s.ResponseFormat, &name) == nil {
+		return name
+	}
+
+	var format struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(s.ResponseFormat, &format) == nil {
+		return format.Type
+	}
+	return ""
+}
+
+// GetRunSettings retrieves the sampling and response format options of a run.
+// Errors are returned as *openai.APIError so callers can check the status code.
+func (c *Client) GetRunSettings(ctx context.Context, threadID string, runID string) (*RunSettings, error) {
+	var settings RunSettings
+	if err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf("/threads/%s/runs/%s", threadID, runID), nil, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// runList is a page of runs
+type runList struct {
+	Data    []openai.Run `json:"data"`
+	LastID  string       `json:"last_id"`
+	HasMore bool         `json:"has_more"`
+}
+
+// ListRuns returns every run of a thread in creation order, following
+// pagination cursors until the API reports no more results
+func (c *Client) ListRuns(ctx context.Context, threadID string) ([]openai.Run, error) {
+	var runs []openai.Run
+	after := ""
+
+	for {
+		query := url.Values{}
+		query.Set("limit", fmt.Sprintf("%d", runsPageSize))
+		query.Set("order", "asc")
+		if after != "" {
+			query.Set("after", after)
+		}
+
+		var page runList
+		path := fmt.Sprintf("/threads/%s/runs?%s", threadID, query.Encode())
+		if err := c.doJSON(ctx, http.MethodGet, path, nil, &page); err != nil {
+			return nil, fmt.Errorf("error listing runs: %w", err)
+		}
+
+		runs = append(runs, page.Data...)
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		after = page.LastID
+		if after == "" {
+			after = page.Data[len(page.Data)-1].ID
+		}
+	}
+
+	return runs, nil
+}
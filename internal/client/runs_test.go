@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetRunSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantTopP       float64
+		wantFormatType string
+	}{
+		{
+			name:           "default response format",
+			body:           `{"id":"run_1","temperature":1.0,"top_p":1.0,"response_format":"auto"}`,
+			wantTopP:       1,
+			wantFormatType: "auto",
+		},
+		{
+			name:           "response format object",
+			body:           `{"id":"run_1","temperature":0.2,"top_p":0.9,"response_format":{"type":"json_object"}}`,
+			wantTopP:       0.9,
+			wantFormatType: "json_object",
+		},
+		{
+			name: "response format missing",
+			body: `{"id":"run_1","top_p":null,"response_format":null}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/threads/thread_1/runs/run_1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				fmt.Fprint(w, tt.body)
+			})
+
+			settings, err := c.GetRunSettings(context.Background(), "thread_1", "run_1")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var topP float64
+			if settings.TopP != nil {
+				topP = *settings.TopP
+			}
+			if topP != tt.wantTopP {
+				t.Errorf("got top_p %g, want %g", topP, tt.wantTopP)
+			}
+			if got := settings.ResponseFormatType(); got != tt.wantFormatType {
+				t.Errorf("got response format %q, want %q", got, tt.wantFormatType)
+			}
+		})
+	}
+}
+
+func TestListRuns(t *testing.T) {
+	tests := []struct {
+		name       string
+		count      int
+		omitLastID bool
+		wantPages  int
+	}{
+		{
+			name:      "empty",
+			wantPages: 1,
+		},
+		{
+			name:      "single page",
+			count:     runsPageSize,
+			wantPages: 1,
+		},
+		{
+			name:      "several pages",
+			count:     runsPageSize + 1,
+			wantPages: 2,
+		},
+		{
+			name:       "pages without last_id",
+			count:      runsPageSize*2 + 1,
+			omitLastID: true,
+			wantPages:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeList{t: t, path: "/threads/thread_1/runs", ids: testIDs("run_", tt.count), omitLastID: tt.omitLastID}
+			c := newTestClient(t, fake.ServeHTTP)
+
+			runs, err := c.ListRuns(context.Background(), "thread_1")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var got []string
+			for _, run := range runs {
+				got = append(got, run.ID)
+			}
+			if len(got) != tt.count || (tt.count > 0 && !reflect.DeepEqual(got, fake.ids)) {
+				t.Errorf("got %d runs, want %d in order", len(got), tt.count)
+			}
+			if len(fake.queries) != tt.wantPages {
+				t.Errorf("got %d requests, want %d", len(fake.queries), tt.wantPages)
+			}
+			for _, query := range fake.queries {
+				if query.Get("order") != "asc" {
+					t.Errorf("got order %q, want asc", query.Get("order"))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// RunStreamResult holds the outcome of a streamed run
+type RunStreamResult struct {
+	// Run is the last run object received on the stream
+	Run *openai.Run
+	// RunID is the ID of the run, recorded from the first event that carries
+	// it. It is set even when the stream fails before a run object is decoded.
+	RunID string
+
+	// text is assembled from thread.message.delta events
+	text strings.Builder
+	// textMessageID is the message the last text delta belonged to
+	textMessageID string
+}
+
+// Text returns the assistant message text assembled from the
+// thread.message.delta events received so far, with the text of each message
+// separated by a blank line
+func (r *RunStreamResult) Text() string {
+	return r.text.String()
+}
+
+// runStreamRequest enables server-sent events on a run request
+type runStreamRequest struct {
+	openai.RunRequest
+	Stream bool `json:"stream"`
+}
+
+// messageDeltaEvent is the payload of a thread.message.delta event
+type messageDeltaEvent struct {
+	ID    string `json:"id"`
+	Delta struct {
+		Content []struct {
+			Index int    `json:"index"`
+			Type  string `json:"type"`
+			Text  *struct {
+				Value string `json:"value"`
+			} `json:"text,omitempty"`
+		} `json:"content"`
+	} `json:"delta"`
+}
+
+// runStreamEventIDs holds the IDs carried by run, step and message events
+type runStreamEventIDs struct {
+	ID    string `json:"id"`
+	RunID string `json:"run_id"`
+}
+
+// CreateRunStream creates a run with streaming enabled and consumes its events
+// until the run finishes, pauses for a required action, or the stream ends.
+// The request context bounds the whole stream, so callers should attach their
+// timeout to it. When the stream fails after the run was created, the partial
+// result is returned alongside the error, with at least RunID set, so the
+// caller can poll or cancel the run.
+func (c *Client) CreateRunStream(ctx context.Context, req *CreateRunRequest) (*RunStreamResult, error) {
+	runRequest, err := buildRunRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/threads/%s/runs", req.ThreadID), runStreamRequest{
+		RunRequest: runRequest,
+		Stream:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("error creating run stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("error creating run stream: %w", errorFromResponse(resp))
+	}
+
+	result := &RunStreamResult{}
+	reader := bufio.NewReader(resp.Body)
+	var event string
+	var data strings.Builder
+
+readLoop:
+	for {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return result, fmt.Errorf("error reading run stream: %w", readErr)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteString("\n")
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case line == "" && (event != "" || data.Len() > 0):
+			// A blank line dispatches the buffered event
+			done, err := c.handleRunStreamEvent(ctx, event, data.String(), result)
+			if err != nil {
+				return result, err
+			}
+			if done {
+				break readLoop
+			}
+			event = ""
+			data.Reset()
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+	}
+
+	if result.Run == nil {
+		return result, fmt.Errorf("run stream ended before a run was created")
+	}
+	return result, nil
+}
+
+// handleRunStreamEvent applies a single event to the stream result and reports
+// whether the run has reached a state where the stream can stop
+func (c *Client) handleRunStreamEvent(ctx context.Context, event string, data string, result *RunStreamResult) (bool, error) {
+	isRunEvent := strings.HasPrefix(event, "thread.run.") && !strings.HasPrefix(event, "thread.run.step.")
+
+	// Record the run ID as soon as any event carries it, so a stream that
+	// breaks later still leaves the caller a run to cancel
+	if result.RunID == "" && strings.HasPrefix(event, "thread.") {
+		var ids runStreamEventIDs
+		if json.Unmarshal([]byte(data), &ids) == nil {
+			if isRunEvent {
+				result.RunID = ids.ID
+			} else {
+				result.RunID = ids.RunID
+			}
+		}
+	}
+
+	switch {
+	case event == "done" || data == "[DONE]":
+		return true, nil
+
+	case event == "error":
+		var apiErr openai.APIError
+		if err := json.Unmarshal([]byte(data), &apiErr); err != nil {
+			return false, fmt.Errorf("run stream error: %s", data)
+		}
+		return false, fmt.Errorf("run stream error: %w", &apiErr)
+
+	case event == "thread.message.delta":
+		var delta messageDeltaEvent
+		if err := json.Unmarshal([]byte(data), &delta); err != nil {
+			return false, fmt.Errorf("error decoding %s event: %v", event, err)
+		}
+		for _, part := range delta.Delta.Content {
+			if part.Type != "text" || part.Text == nil {
+				continue
+			}
+			if result.textMessageID != delta.ID && result.text.Len() > 0 {
+				result.text.WriteString("\n\n")
+			}
+			result.textMessageID = delta.ID
+			result.text.WriteString(part.Text.Value)
+		}
+		return false, nil
+
+	case isRunEvent:
+		var run openai.Run
+		if err := json.Unmarshal([]byte(data), &run); err != nil {
+			return false, fmt.Errorf("error decoding %s event: %v", event, err)
+		}
+		result.Run = &run
+		result.RunID = run.ID
+
+		tflog.Debug(ctx, "Run stream event", map[string]interface{}{
+			"event":  event,
+			"run_id": run.ID,
+			"status": string(run.Status),
+		})
+
+		switch run.Status {
+		case openai.RunStatusCompleted, openai.RunStatusIncomplete, openai.RunStatusFailed,
+			openai.RunStatusCancelled, openai.RunStatusExpired, openai.RunStatusRequiresAction:
+			return true, nil
+		}
+		return false, nil
+	}
+
+	// Step and other message events carry nothing else we track
+	return false, nil
+}
@@ -0,0 +1,296 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// sseEvent formats a server-sent event
+func sseEvent(event string, data string) string {
+	return fmt.Sprintf("event: %s\ndata: %s\n\n", event, data)
+}
+
+// runEventData returns the data of a run event with the given status
+func runEventData(status openai.RunStatus) string {
+	return fmt.Sprintf(`{"id":"run_1","object":"thread.run","thread_id":"thread_1","status":%q}`, status)
+}
+
+func TestHandleRunStreamEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		event      string
+		data       string
+		wantDone   bool
+		wantErr    bool
+		wantRunID  string
+		wantStatus openai.RunStatus
+	}{
+		{
+			name:       "run created",
+			event:      "thread.run.created",
+			data:       runEventData(openai.RunStatusQueued),
+			wantRunID:  "run_1",
+			wantStatus: openai.RunStatusQueued,
+		},
+		{
+			name:       "run in progress",
+			event:      "thread.run.in_progress",
+			data:       runEventData(openai.RunStatusInProgress),
+			wantRunID:  "run_1",
+			wantStatus: openai.RunStatusInProgress,
+		},
+		{
+			name:       "run completed",
+			event:      "thread.run.completed",
+			data:       runEventData(openai.RunStatusCompleted),
+			wantDone:   true,
+			wantRunID:  "run_1",
+			wantStatus: openai.RunStatusCompleted,
+		},
+		{
+			name:       "run requires action",
+			event:      "thread.run.requires_action",
+			data:       runEventData(openai.RunStatusRequiresAction),
+			wantDone:   true,
+			wantRunID:  "run_1",
+			wantStatus: openai.RunStatusRequiresAction,
+		},
+		{
+			name:       "run failed",
+			event:      "thread.run.failed",
+			data:       runEventData(openai.RunStatusFailed),
+			wantDone:   true,
+			wantRunID:  "run_1",
+			wantStatus: openai.RunStatusFailed,
+		},
+		{
+			name:      "step events record the run ID",
+			event:     "thread.run.step.created",
+			data:      `{"id":"step_1","object":"thread.run.step","run_id":"run_1","status":"in_progress"}`,
+			wantRunID: "run_1",
+		},
+		{
+			name:      "message events record the run ID",
+			event:     "thread.message.delta",
+			data:      `{"id":"msg_1","object":"thread.message.delta","run_id":"run_1","delta":{}}`,
+			wantRunID: "run_1",
+		},
+		{
+			name:    "malformed message delta",
+			event:   "thread.message.delta",
+			data:    `{"id":"msg_1","delta":{"content":"Hi"}}`,
+			wantErr: true,
+		},
+		{
+			name:  "thread events without a run",
+			event: "thread.created",
+			data:  `{"id":"thread_1","object":"thread"}`,
+		},
+		{
+			name:     "done event",
+			event:    "done",
+			data:     "[DONE]",
+			wantDone: true,
+		},
+		{
+			name:    "error event",
+			event:   "error",
+			data:    `{"message":"server overloaded","type":"server_error"}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed run event",
+			event:   "thread.run.created",
+			data:    `{"id":`,
+			wantErr: true,
+		},
+	}
+
+	c := &Client{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &RunStreamResult{}
+			done, err := c.handleRunStreamEvent(context.Background(), tt.event, tt.data, result)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tt.wantErr)
+			}
+			if done != tt.wantDone {
+				t.Errorf("got done %t, want %t", done, tt.wantDone)
+			}
+			if result.RunID != tt.wantRunID {
+				t.Errorf("got run ID %q, want %q", result.RunID, tt.wantRunID)
+			}
+			if tt.wantStatus == "" {
+				if result.Run != nil {
+					t.Errorf("got run %+v, want none", result.Run)
+				}
+			} else if result.Run == nil || result.Run.Status != tt.wantStatus {
+				t.Errorf("got run %+v, want status %s", result.Run, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestHandleRunStreamEventAssemblesText(t *testing.T) {
+	events := []string{
+		`{"id":"msg_1","delta":{"content":[{"index":0,"type":"text","text":{"value":"Hel"}}]}}`,
+		`{"id":"msg_1","delta":{"content":[{"index":0,"type":"text","text":{"value":"lo"}}]}}`,
+		`{"id":"msg_1","delta":{"content":[{"index":1,"type":"image_file","image_file":{"file_id":"file-1"}}]}}`,
+		`{"id":"msg_2","delta":{"content":[{"index":0,"type":"text","text":{"value":"Bye"}}]}}`,
+	}
+
+	c := &Client{}
+	result := &RunStreamResult{}
+	for _, data := range events {
+		if _, err := c.handleRunStreamEvent(context.Background(), "thread.message.delta", data, result); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if got, want := result.Text(), "Hello\n\nBye"; got != want {
+		t.Fatalf("got text %q, want %q", got, want)
+	}
+}
+
+func TestHandleRunStreamEventKeepsFirstRunID(t *testing.T) {
+	c := &Client{}
+	result := &RunStreamResult{RunID: "run_1"}
+
+	_, err := c.handleRunStreamEvent(context.Background(), "thread.message.created", `{"id":"msg_1","run_id":"run_2"}`, result)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result.RunID != "run_1" {
+		t.Fatalf("got run ID %q, want the first one recorded", result.RunID)
+	}
+}
+
+func TestCreateRunStream(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantErr    string
+		wantRunID  string
+		wantStatus openai.RunStatus
+		wantText   string
+	}{
+		{
+			name: "completed",
+			body: sseEvent("thread.run.created", runEventData(openai.RunStatusQueued)) +
+				sseEvent("thread.run.in_progress", runEventData(openai.RunStatusInProgress)) +
+				sseEvent("thread.message.delta", `{"id":"msg_1","run_id":"run_1","delta":{"content":[{"type":"text","text":{"value":"Hi"}}]}}`) +
+				sseEvent("thread.run.completed", runEventData(openai.RunStatusCompleted)) +
+				sseEvent("done", "[DONE]"),
+			wantRunID:  "run_1",
+			wantStatus: openai.RunStatusCompleted,
+			wantText:   "Hi",
+		},
+		{
+			name: "multi-line data and carriage returns",
+			body: "event: thread.run.created\r\ndata: {\"id\":\"run_1\",\r\ndata: \"status\":\"queued\"}\r\n\r\n" +
+				sseEvent("thread.run.requires_action", runEventData(openai.RunStatusRequiresAction)),
+			wantRunID:  "run_1",
+			wantStatus: openai.RunStatusRequiresAction,
+		},
+		{
+			name: "stream ends while the run is in progress",
+			body: sseEvent("thread.run.created", runEventData(openai.RunStatusQueued)) +
+				sseEvent("thread.run.in_progress", runEventData(openai.RunStatusInProgress)),
+			wantRunID:  "run_1",
+			wantStatus: openai.RunStatusInProgress,
+		},
+		{
+			name:      "stream ends before a run object",
+			body:      sseEvent("thread.run.step.created", `{"id":"step_1","run_id":"run_1"}`),
+			wantErr:   "run stream ended before a run was created",
+			wantRunID: "run_1",
+		},
+		{
+			name: "error event",
+			body: sseEvent("thread.run.created", runEventData(openai.RunStatusQueued)) +
+				sseEvent("error", `{"message":"server overloaded","type":"server_error"}`),
+			wantErr:    "server overloaded",
+			wantRunID:  "run_1",
+			wantStatus: openai.RunStatusQueued,
+		},
+		{
+			name:    "rejected request",
+			status:  http.StatusBadRequest,
+			body:    `{"error":{"message":"assistant not found","type":"invalid_request_error"}}`,
+			wantErr: "assistant not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/threads/thread_1/runs" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if got := r.Header.Get("Accept"); got != "text/event-stream" {
+					t.Errorf("got Accept %q, want text/event-stream", got)
+				}
+				var body map[string]interface{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["stream"] != true || body["assistant_id"] != "asst_1" {
+					t.Errorf("got request body %v, want a streamed run for asst_1", body)
+				}
+
+				if tt.status != 0 {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(tt.status)
+				} else {
+					w.Header().Set("Content-Type", "text/event-stream")
+				}
+				fmt.Fprint(w, tt.body)
+			})
+
+			result, err := c.CreateRunStream(context.Background(), &CreateRunRequest{ThreadID: "thread_1", AssistantID: "asst_1"})
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+			}
+
+			var runID, text string
+			var status openai.RunStatus
+			if result != nil {
+				runID = result.RunID
+				text = result.Text()
+				if result.Run != nil {
+					status = result.Run.Status
+				}
+			}
+			if runID != tt.wantRunID {
+				t.Errorf("got run ID %q, want %q", runID, tt.wantRunID)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("got status %q, want %q", status, tt.wantStatus)
+			}
+			if text != tt.wantText {
+				t.Errorf("got text %q, want %q", text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestCreateRunStreamAPIError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"message":"No thread found","type":"invalid_request_error"}}`)
+	})
+
+	_, err := c.CreateRunStream(context.Background(), &CreateRunRequest{ThreadID: "thread_1", AssistantID: "asst_1"})
+
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusNotFound {
+		t.Fatalf("got error %v, want a 404 API error", err)
+	}
+}
@@ -0,0 +1,323 @@
+package client
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const (
+	// UploadMaxPartSize is the largest part the Uploads API accepts
+	UploadMaxPartSize = 64 * 1024 * 1024
+	// UploadPartConcurrency is the number of parts sent at the same time
+	UploadPartConcurrency = 4
+)
+
+// Upload is an upload created through the Uploads API
+type Upload struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	Bytes     int64  `json:"bytes"`
+	CreatedAt int64  `json:"created_at"`
+	Filename  string `json:"filename"`
+	Purpose   string `json:"purpose"`
+	Status    string `json:"status"`
+	ExpiresAt int64  `json:"expires_at"`
+	File      *File  `json:"file"`
+}
+
+// UploadPart is a chunk of bytes added to an upload
+type UploadPart struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	CreatedAt int64  `json:"created_at"`
+	UploadID  string `json:"upload_id"`
+}
+
+// CreateUploadRequest describes the file an upload will produce
+type CreateUploadRequest struct {
+	Filename     string            `json:"filename"`
+	Purpose      string            `json:"purpose"`
+	Bytes        int64             `json:"bytes"`
+	MimeType     string            `json:"mime_type"`
+	ExpiresAfter *FileExpiresAfter `json:"expires_after,omitempty"`
+}
+
+// completeUploadRequest lists the parts of an upload in order
+type completeUploadRequest struct {
+	PartIDs []string `json:"part_ids"`
+	MD5     string   `json:"md5,omitempty"`
+}
+
+// UploadFileRequest describes a file sent in parts through the Uploads API
+type UploadFileRequest struct {
+	Filename     string
+	Purpose      string
+	MimeType     string
+	Reader       io.ReaderAt
+	Size         int64
+	PartSize     int64
+	ExpiresAfter *FileExpiresAfter
+}
+
+// CreateUpload starts an upload that parts can be added to
+func (c *Client) CreateUpload(ctx context.Context, req CreateUploadRequest) (*Upload, error) {
+	var upload Upload
+	if err := c.doJSON(ctx, http.MethodPost, "/uploads", req, &upload); err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// AddUploadPart adds a chunk of bytes to an upload. The request body is
+// streamed from data, so the part is never held in memory.
+func (c *Client) AddUploadPart(ctx context.Context, uploadID string, data io.Reader) (*UploadPart, error) {
+	body, pw := io.Pipe()
+	form := multipart.NewWriter(pw)
+
+	// The form is written while the request is sent. A failure while writing
+	// fails the request, and a request that ends early closes the pipe.
+	go func() {
+		pw.CloseWithError(writeUploadPartForm(form, data))
+	}()
+
+	req, err := c.newRawRequest(ctx, http.MethodPost, fmt.Sprintf("/uploads/%s/parts", uploadID), form.FormDataContentType(), body)
+	if err != nil {
+		body.CloseWithError(err)
+		return nil, err
+	}
+
+	var part UploadPart
+	if err := c.do(req, &part); err != nil {
+		return nil, err
+	}
+	return &part, nil
+}
+
+// writeUploadPartForm writes the content of an upload part to form
+func writeUploadPartForm(form *multipart.Writer, data io.Reader) error {
+	w, err := form.CreateFormFile("data", "part")
+	if err != nil {
+		return fmt.Errorf("error building upload part: %w", err)
+	}
+	if _, err := io.Copy(w, data); err != nil {
+		return &uploadSourceError{err: err}
+	}
+	return form.Close()
+}
+
+// uploadSourceError is a failure to read the source of an upload part, which
+// retrying the part does not fix
+type uploadSourceError struct {
+	err error
+}
+
+func (e *uploadSourceError) Error() string {
+	return fmt.Sprintf("error reading file: %v", e.err)
+}
+
+func (e *uploadSourceError) Unwrap() error {
+	return e.err
+}
+
+// cancelUpload cancels an upload that could not be completed. This is best
+// effort, the upload expires on its own otherwise.
+func (c *Client) cancelUpload(ctx context.Context, uploadID string) {
+	if _, err := c.CancelUpload(context.WithoutCancel(ctx), uploadID); err != nil {
+		tflog.Warn(ctx, "Unable to cancel upload", map[string]interface{}{
+			"upload_id": uploadID,
+			"error":     err.Error(),
+		})
+	}
+}
+
+// CompleteUpload assembles the parts in order into a file. When md5 is set
+// the API rejects the upload if the assembled bytes do not match it.
+func (c *Client) CompleteUpload(ctx context.Context, uploadID string, partIDs []string, md5 string) (*Upload, error) {
+	var upload Upload
+	req := completeUploadRequest{PartIDs: partIDs, MD5: md5}
+	if err := c.doJSON(ctx, http.MethodPost, fmt.Sprintf("/uploads/%s/complete", uploadID), req, &upload); err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// CancelUpload cancels an upload so no parts can be added to it
+func (c *Client) CancelUpload(ctx context.Context, uploadID string) (*Upload, error) {
+	var upload Upload
+	if err := c.doJSON(ctx, http.MethodPost, fmt.Sprintf("/uploads/%s/cancel", uploadID), nil, &upload); err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// UploadFile sends a file through the Uploads API. Parts are streamed from
+// the source concurrently and retried individually, and the upload is
+// completed with the MD5 checksum of the source. The upload is cancelled if
+// any part fails or it cannot be completed.
+func (c *Client) UploadFile(ctx context.Context, req UploadFileRequest) (*File, error) {
+	if req.PartSize <= 0 || req.PartSize > UploadMaxPartSize {
+		return nil, fmt.Errorf("part size must be between 1 and %d bytes, got %d", UploadMaxPartSize, req.PartSize)
+	}
+
+	// Parts finish out of order, so they cannot feed a single hash without
+	// being held in memory. The checksum is streamed from the source while
+	// the parts are sent instead.
+	checksum := make(chan uploadChecksum, 1)
+	go func() {
+		hash := md5.New()
+		_, err := io.Copy(hash, io.NewSectionReader(req.Reader, 0, req.Size))
+		checksum <- uploadChecksum{sum: hex.EncodeToString(hash.Sum(nil)), err: err}
+	}()
+
+	upload, err := c.CreateUpload(ctx, CreateUploadRequest{
+		Filename:     req.Filename,
+		Purpose:      req.Purpose,
+		Bytes:        req.Size,
+		MimeType:     req.MimeType,
+		ExpiresAfter: req.ExpiresAfter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	partCount := int((req.Size + req.PartSize - 1) / req.PartSize)
+	tflog.Debug(ctx, "Sending upload parts", map[string]interface{}{
+		"upload_id": upload.ID,
+		"parts":     partCount,
+		"part_size": req.PartSize,
+	})
+
+	partIDs, err := c.sendUploadParts(ctx, upload.ID, req, partCount)
+	if err != nil {
+		c.cancelUpload(ctx, upload.ID)
+		return nil, err
+	}
+
+	sum := <-checksum
+	if sum.err != nil {
+		c.cancelUpload(ctx, upload.ID)
+		return nil, fmt.Errorf("error reading file: %w", sum.err)
+	}
+
+	completed, err := c.CompleteUpload(ctx, upload.ID, partIDs, sum.sum)
+	if err != nil {
+		c.cancelUpload(ctx, upload.ID)
+		return nil, err
+	}
+	if completed.File == nil {
+		return nil, fmt.Errorf("upload %s completed with status %q but returned no file", upload.ID, completed.Status)
+	}
+	return completed.File, nil
+}
+
+// uploadChecksum is the MD5 checksum of an upload's source, or the error that
+// kept it from being read
+type uploadChecksum struct {
+	sum string
+	err error
+}
+
+// sendUploadParts sends every part of the source and returns the part IDs in
+// order. The first part that fails after its retries stops the others.
+func (c *Client) sendUploadParts(ctx context.Context, uploadID string, req UploadFileRequest, partCount int) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	partIDs := make([]string, partCount)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for w := 0; w < UploadPartConcurrency && w < partCount; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				id, err := c.sendUploadPart(ctx, uploadID, req, i)
+				if err != nil {
+					once.Do(func() {
+						firstErr = fmt.Errorf("error sending part %d of %d: %w", i+1, partCount, err)
+						cancel()
+					})
+					continue
+				}
+				partIDs[i] = id
+			}
+		}()
+	}
+
+	for i := 0; i < partCount; i++ {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return partIDs, nil
+}
+
+// sendUploadPart streams a single part from the source, retrying transient
+// failures with exponential backoff. Each attempt reads the part again.
+func (c *Client) sendUploadPart(ctx context.Context, uploadID string, req UploadFileRequest, index int) (string, error) {
+	offset := int64(index) * req.PartSize
+	size := req.PartSize
+	if offset+size > req.Size {
+		size = req.Size - offset
+	}
+
+	var err error
+	for attempt := 0; attempt < RetryMaxAttempts; attempt++ {
+		var part *UploadPart
+		part, err = c.AddUploadPart(ctx, uploadID, io.NewSectionReader(req.Reader, offset, size))
+		if err == nil {
+			return part.ID, nil
+		}
+
+		// Network errors are retried along with rate limits and server errors
+		if _, isAPIErr := err.(*openai.APIError); isAPIErr && !c.isRetryableError(err) {
+			return "", err
+		}
+		var sourceErr *uploadSourceError
+		if errors.As(err, &sourceErr) {
+			return "", sourceErr
+		}
+
+		backoff := c.calculateBackoff(attempt)
+		tflog.Debug(ctx, "Retrying upload part", map[string]interface{}{
+			"upload_id": uploadID,
+			"part":      index + 1,
+			"attempt":   attempt + 1,
+			"error":     err.Error(),
+		})
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+
+	return "", fmt.Errorf("failed after %d attempts: %w", RetryMaxAttempts, err)
+}
@@ -0,0 +1,219 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeUploads is an in-memory Uploads API
+type fakeUploads struct {
+	t *testing.T
+
+	mu        sync.Mutex
+	created   CreateUploadRequest
+	parts     map[string][]byte
+	completed *completeUploadRequest
+	cancelled bool
+
+	// failPart rejects parts holding these bytes
+	failPart []byte
+	// failComplete rejects the completion
+	failComplete bool
+	// truncatedParts expects parts cut short by a source that fails to read
+	truncatedParts bool
+}
+
+func (f *fakeUploads) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/uploads":
+		if err := json.NewDecoder(r.Body).Decode(&f.created); err != nil {
+			f.t.Errorf("decoding upload: %s", err)
+		}
+		fmt.Fprint(w, `{"id":"upload_1","status":"pending"}`)
+
+	case "/uploads/upload_1/parts":
+		file, _, err := r.FormFile("data")
+		if err != nil {
+			if !f.truncatedParts {
+				f.t.Errorf("reading part: %s", err)
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := io.ReadAll(file)
+		if f.failPart != nil && bytes.Equal(data, f.failPart) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"part rejected","type":"invalid_request_error"}}`)
+			return
+		}
+		id := fmt.Sprintf("part_%d", len(f.parts)+1)
+		f.parts[id] = data
+		fmt.Fprintf(w, `{"id":%q,"upload_id":"upload_1"}`, id)
+
+	case "/uploads/upload_1/complete":
+		if f.failComplete {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"checksum mismatch","type":"invalid_request_error"}}`)
+			return
+		}
+		f.completed = &completeUploadRequest{}
+		if err := json.NewDecoder(r.Body).Decode(f.completed); err != nil {
+			f.t.Errorf("decoding completion: %s", err)
+		}
+		fmt.Fprintf(w, `{"id":"upload_1","status":"completed","file":{"id":"file-1","filename":%q}}`, f.created.Filename)
+
+	case "/uploads/upload_1/cancel":
+		f.cancelled = true
+		fmt.Fprint(w, `{"id":"upload_1","status":"cancelled"}`)
+
+	default:
+		f.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// assembled returns the bytes of the completed upload in part order
+func (f *fakeUploads) assembled() []byte {
+	var b bytes.Buffer
+	for _, id := range f.completed.PartIDs {
+		b.Write(f.parts[id])
+	}
+	return b.Bytes()
+}
+
+// failingReader fails every read past failAt
+type failingReader struct {
+	data   []byte
+	failAt int64
+}
+
+func (r *failingReader) ReadAt(p []byte, off int64) (int, error) {
+	if off+int64(len(p)) > r.failAt {
+		return 0, errors.New("disk read failed")
+	}
+	return bytes.NewReader(r.data).ReadAt(p, off)
+}
+
+func TestUploadFile(t *testing.T) {
+	content := []byte("0123456789abcdefghij")
+
+	tests := []struct {
+		name          string
+		partSize      int64
+		reader        io.ReaderAt
+		failPart      []byte
+		failComplete  bool
+		truncated     bool
+		wantErr       string
+		wantParts     int
+		wantCancelled bool
+	}{
+		{
+			name:      "single part",
+			partSize:  int64(len(content)),
+			wantParts: 1,
+		},
+		{
+			name:      "several parts",
+			partSize:  3,
+			wantParts: 7,
+		},
+		{
+			name:          "part rejected",
+			partSize:      4,
+			failPart:      []byte("89ab"),
+			wantErr:       "error sending part 3 of 5",
+			wantCancelled: true,
+		},
+		{
+			name:          "completion rejected",
+			partSize:      4,
+			failComplete:  true,
+			wantErr:       "checksum mismatch",
+			wantCancelled: true,
+		},
+		{
+			name:          "source read fails",
+			partSize:      4,
+			reader:        &failingReader{data: content, failAt: 10},
+			truncated:     true,
+			wantErr:       "disk read failed",
+			wantCancelled: true,
+		},
+		{
+			name:     "part size too large",
+			partSize: UploadMaxPartSize + 1,
+			wantErr:  "part size must be between 1 and",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeUploads{
+				t:              t,
+				parts:          make(map[string][]byte),
+				failPart:       tt.failPart,
+				failComplete:   tt.failComplete,
+				truncatedParts: tt.truncated,
+			}
+			c := newTestClient(t, fake.ServeHTTP)
+
+			reader := tt.reader
+			if reader == nil {
+				reader = bytes.NewReader(content)
+			}
+
+			file, err := c.UploadFile(context.Background(), UploadFileRequest{
+				Filename: "data.jsonl",
+				Purpose:  "batch",
+				MimeType: "application/jsonl",
+				Reader:   reader,
+				Size:     int64(len(content)),
+				PartSize: tt.partSize,
+			})
+
+			if fake.cancelled != tt.wantCancelled {
+				t.Errorf("got cancelled %t, want %t", fake.cancelled, tt.wantCancelled)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if file.ID != "file-1" || file.FileName != "data.jsonl" {
+				t.Errorf("got file %+v, want file-1 named data.jsonl", file)
+			}
+			if fake.created.Filename != "data.jsonl" || fake.created.Bytes != int64(len(content)) || fake.created.MimeType != "application/jsonl" {
+				t.Errorf("got upload %+v", fake.created)
+			}
+			if len(fake.completed.PartIDs) != tt.wantParts {
+				t.Errorf("got %d parts, want %d", len(fake.completed.PartIDs), tt.wantParts)
+			}
+			if got := fake.assembled(); !bytes.Equal(got, content) {
+				t.Errorf("got assembled content %q, want %q", got, content)
+			}
+			sum := md5.Sum(content)
+			if fake.completed.MD5 != hex.EncodeToString(sum[:]) {
+				t.Errorf("got md5 %q, want the checksum of the content", fake.completed.MD5)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// ChunkingStrategy controls how files are split into chunks when they are
+// added to a vector store
+type ChunkingStrategy struct {
+	Type   string                  `json:"type"`
+	Static *StaticChunkingStrategy `json:"static,omitempty"`
+}
+
+// StaticChunkingStrategy splits files into chunks of a fixed size
+type StaticChunkingStrategy struct {
+	MaxChunkSizeTokens int64 `json:"max_chunk_size_tokens"`
+	ChunkOverlapTokens int64 `json:"chunk_overlap_tokens"`
+}
+
+// CreateVectorStoreRequest is a vector store request including the chunking
+// strategy, which the go-openai VectorStoreRequest does not carry
+type CreateVectorStoreRequest struct {
+	Name             string                     `json:"name,omitempty"`
+	FileIDs          []string                   `json:"file_ids,omitempty"`
+	ExpiresAfter     *openai.VectorStoreExpires `json:"expires_after,omitempty"`
+	ChunkingStrategy *ChunkingStrategy          `json:"chunking_strategy,omitempty"`
+	Metadata         map[string]interface{}     `json:"metadata,omitempty"`
+}
+
+// VectorStoreFile is a file attached to a vector store including its last
+// error and chunking strategy, which the go-openai VectorStoreFile type does
+// not decode
+type VectorStoreFile struct {
+	ID               string                 `json:"id"`
+	Object           string                 `json:"object"`
+	CreatedAt        int64                  `json:"created_at"`
+	VectorStoreID    string                 `json:"vector_store_id"`
+	UsageBytes       int64                  `json:"usage_bytes"`
+	Status           string                 `json:"status"`
+	LastError        *VectorStoreFileError  `json:"last_error,omitempty"`
+	ChunkingStrategy *ChunkingStrategy      `json:"chunking_strategy,omitempty"`
+	Attributes       map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// VectorStoreFileError describes why a file could not be indexed
+type VectorStoreFileError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// CreateVectorStoreFileRequest describes a file to attach to a vector store
+type CreateVectorStoreFileRequest struct {
+	FileID           string                 `json:"file_id"`
+	ChunkingStrategy *ChunkingStrategy      `json:"chunking_strategy,omitempty"`
+	Attributes       map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// vectorStoreFilesPageSize is the largest page size accepted when listing
+// vector store files
+const vectorStoreFilesPageSize = 100
+
+// VectorStoreFileBatchMaxFiles is the largest number of files a single file
+// batch accepts
+const VectorStoreFileBatchMaxFiles = 500
+
+// VectorStoreFileBatch is a batch of files attached to a vector store together
+type VectorStoreFileBatch struct {
+	ID            string                      `json:"id"`
+	Object        string                      `json:"object"`
+	CreatedAt     int64                       `json:"created_at"`
+	VectorStoreID string                      `json:"vector_store_id"`
+	Status        string                      `json:"status"`
+	FileCounts    openai.VectorStoreFileCount `json:"file_counts"`
+}
+
+// CreateVectorStoreFileBatchRequest describes files to attach to a vector
+// store with a shared chunking strategy and attributes, which the go-openai
+// VectorStoreFileBatchRequest does not carry
+type CreateVectorStoreFileBatchRequest struct {
+	FileIDs          []string               `json:"file_ids"`
+	ChunkingStrategy *ChunkingStrategy      `json:"chunking_strategy,omitempty"`
+	Attributes       map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// updateVectorStoreFileRequest replaces the attributes of a vector store file
+type updateVectorStoreFileRequest struct {
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// vectorStoreFileList is a page of vector store files
+type vectorStoreFileList struct {
+	Data    []VectorStoreFile `json:"data"`
+	LastID  string            `json:"last_id"`
+	HasMore bool              `json:"has_more"`
+}
+
+// CreateVectorStore creates a vector store, chunking any initial files with
+// the requested strategy
+func (c *Client) CreateVectorStore(ctx context.Context, req CreateVectorStoreRequest) (*openai.VectorStore, error) {
+	var store openai.VectorStore
+	if err := c.doJSON(ctx, http.MethodPost, "/vector_stores", req, &store); err != nil {
+		return nil, err
+	}
+	return &store, nil
+}
+
+// CreateVectorStoreFile attaches a file to a vector store. Errors are returned
+// as *openai.APIError so callers can check the status code.
+func (c *Client) CreateVectorStoreFile(ctx context.Context, vectorStoreID string, req CreateVectorStoreFileRequest) (*VectorStoreFile, error) {
+	var file VectorStoreFile
+	if err := c.doJSON(ctx, http.MethodPost, fmt.Sprintf("/vector_stores/%s/files", vectorStoreID), req, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// GetVectorStoreFile retrieves a file attached to a vector store. Errors are
+// returned as *openai.APIError so callers can check the status code.
+func (c *Client) GetVectorStoreFile(ctx context.Context, vectorStoreID string, fileID string) (*VectorStoreFile, error) {
+	var file VectorStoreFile
+	if err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf("/vector_stores/%s/files/%s", vectorStoreID, fileID), nil, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// UpdateVectorStoreFileAttributes replaces the attributes of a file attached
+// to a vector store
+func (c *Client) UpdateVectorStoreFileAttributes(ctx context.Context, vectorStoreID string, fileID string, attributes map[string]interface{}) (*VectorStoreFile, error) {
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+
+	var file VectorStoreFile
+	req := updateVectorStoreFileRequest{Attributes: attributes}
+	if err := c.doJSON(ctx, http.MethodPost, fmt.Sprintf("/vector_stores/%s/files/%s", vectorStoreID, fileID), req, &file); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// ListVectorStoreFiles returns every file attached to a vector store,
+// following pagination cursors until the API reports no more results
+func (c *Client) ListVectorStoreFiles(ctx context.Context, vectorStoreID string) ([]VectorStoreFile, error) {
+	return c.listVectorStoreFiles(ctx, fmt.Sprintf("/vector_stores/%s/files", vectorStoreID), "")
+}
+
+// CreateVectorStoreFileBatch attaches up to VectorStoreFileBatchMaxFiles
+// files to a vector store in a single request
+func (c *Client) CreateVectorStoreFileBatch(ctx context.Context, vectorStoreID string, req CreateVectorStoreFileBatchRequest) (*VectorStoreFileBatch, error) {
+	var batch VectorStoreFileBatch
+	if err := c.doJSON(ctx, http.MethodPost, fmt.Sprintf("/vector_stores/%s/file_batches", vectorStoreID), req, &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// GetVectorStoreFileBatch retrieves a file batch and its file counts
+func (c *Client) GetVectorStoreFileBatch(ctx context.Context, vectorStoreID string, batchID string) (*VectorStoreFileBatch, error) {
+	var batch VectorStoreFileBatch
+	if err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf("/vector_stores/%s/file_batches/%s", vectorStoreID, batchID), nil, &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// ListVectorStoreFileBatchFiles returns the files in a file batch. When
+// filter is set only files with that status are returned.
+func (c *Client) ListVectorStoreFileBatchFiles(ctx context.Context, vectorStoreID string, batchID string, filter string) ([]VectorStoreFile, error) {
+	return c.listVectorStoreFiles(ctx, fmt.Sprintf("/vector_stores/%s/file_batches/%s/files", vectorStoreID, batchID), filter)
+}
+
+// listVectorStoreFiles follows the pagination cursors of a vector store file
+// listing endpoint
+func (c *Client) listVectorStoreFiles(ctx context.Context, basePath string, filter string) ([]VectorStoreFile, error) {
+	var files []VectorStoreFile
+	after := ""
+
+	for {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(vectorStoreFilesPageSize))
+		if filter != "" {
+			query.Set("filter", filter)
+		}
+		if after != "" {
+			query.Set("after", after)
+		}
+
+		var page vectorStoreFileList
+		if err := c.doJSON(ctx, http.MethodGet, basePath+"?"+query.Encode(), nil, &page); err != nil {
+			return nil, fmt.Errorf("error listing vector store files: %w", err)
+		}
+
+		files = append(files, page.Data...)
+		if !page.HasMore || len(page.Data) == 0 {
+			break
+		}
+		after = page.LastID
+		if after == "" {
+			after = page.Data[len(page.Data)-1].ID
+		}
+	}
+
+	return files, nil
+}
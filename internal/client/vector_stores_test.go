@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestListVectorStoreFiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		count      int
+		omitLastID bool
+		wantPages  int
+	}{
+		{
+			name:      "empty",
+			wantPages: 1,
+		},
+		{
+			name:      "several pages",
+			count:     vectorStoreFilesPageSize*2 + 1,
+			wantPages: 3,
+		},
+		{
+			name:       "pages without last_id",
+			count:      vectorStoreFilesPageSize + 1,
+			omitLastID: true,
+			wantPages:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeList{t: t, path: "/vector_stores/vs_1/files", ids: testIDs("file-", tt.count), omitLastID: tt.omitLastID}
+			c := newTestClient(t, fake.ServeHTTP)
+
+			files, err := c.ListVectorStoreFiles(context.Background(), "vs_1")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var got []string
+			for _, file := range files {
+				got = append(got, file.ID)
+			}
+			if len(got) != tt.count || (tt.count > 0 && !reflect.DeepEqual(got, fake.ids)) {
+				t.Errorf("got %d files, want %d in order", len(got), tt.count)
+			}
+			if len(fake.queries) != tt.wantPages {
+				t.Errorf("got %d requests, want %d", len(fake.queries), tt.wantPages)
+			}
+			for _, query := range fake.queries {
+				if query.Has("filter") {
+					t.Errorf("got filter %q, want none", query.Get("filter"))
+				}
+			}
+		})
+	}
+}
+
+func TestListVectorStoreFileBatchFiles(t *testing.T) {
+	fake := &fakeList{t: t, path: "/vector_stores/vs_1/file_batches/vsfb_1/files", ids: testIDs("file-", vectorStoreFilesPageSize+1)}
+	c := newTestClient(t, fake.ServeHTTP)
+
+	files, err := c.ListVectorStoreFileBatchFiles(context.Background(), "vs_1", "vsfb_1", "failed")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(files) != len(fake.ids) {
+		t.Errorf("got %d files, want %d", len(files), len(fake.ids))
+	}
+	if len(fake.queries) != 2 {
+		t.Errorf("got %d requests, want 2", len(fake.queries))
+	}
+	for _, query := range fake.queries {
+		if query.Get("filter") != "failed" {
+			t.Errorf("got filter %q, want failed", query.Get("filter"))
+		}
+	}
+}
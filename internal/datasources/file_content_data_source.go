@@ -0,0 +1,234 @@
+package datasources
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/darnold/terraform-provider-openai/internal/fsutil"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultFileContentMaxBytes caps downloads when max_bytes is not set
+const defaultFileContentMaxBytes = 10 * 1024 * 1024
+
+// errFileContentTooLarge is returned by cappedBuffer once the cap is exceeded
+var errFileContentTooLarge = errors.New("file content exceeds max_bytes")
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &FileContentDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &FileContentDataSource{}
+
+func NewFileContentDataSource() datasource.DataSource {
+	return &FileContentDataSource{}
+}
+
+// FileContentDataSource defines the data source implementation.
+type FileContentDataSource struct {
+	client *client.Client
+}
+
+// FileContentDataSourceModel describes the data source data model.
+type FileContentDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	FileID        types.String `tfsdk:"file_id"`
+	OutputPath    types.String `tfsdk:"output_path"`
+	MaxBytes      types.Int64  `tfsdk:"max_bytes"`
+	Filename      types.String `tfsdk:"filename"`
+	Bytes         types.Int64  `tfsdk:"bytes"`
+	Content       types.String `tfsdk:"content"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	SHA256        types.String `tfsdk:"sha256"`
+}
+
+func (d *FileContentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_file_content"
+}
+
+func (d *FileContentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to download the content of a file uploaded to or generated by OpenAI.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the file.",
+				Computed:            true,
+			},
+			"file_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the file to download.",
+				Required:            true,
+			},
+			"output_path": schema.StringAttribute{
+				MarkdownDescription: "A local path to also write the content to. Missing parent directories are created.",
+				Optional:            true,
+			},
+			"max_bytes": schema.Int64Attribute{
+				MarkdownDescription: "The largest file that may be downloaded, in bytes. Defaults to 10 MiB.",
+				Optional:            true,
+			},
+			"filename": schema.StringAttribute{
+				MarkdownDescription: "The name of the file.",
+				Computed:            true,
+			},
+			"bytes": schema.Int64Attribute{
+				MarkdownDescription: "The size of the downloaded content in bytes.",
+				Computed:            true,
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The content as text. Null when the content is not valid UTF-8.",
+				Computed:            true,
+			},
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "The content encoded as base64.",
+				Computed:            true,
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "The hex-encoded SHA-256 checksum of the content.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *FileContentDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data FileContentDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.MaxBytes.IsNull() && !data.MaxBytes.IsUnknown() && data.MaxBytes.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_bytes"),
+			"Invalid Max Bytes",
+			fmt.Sprintf("max_bytes must be at least 1, got %d", data.MaxBytes.ValueInt64()),
+		)
+	}
+
+	if !data.OutputPath.IsNull() && !data.OutputPath.IsUnknown() && data.OutputPath.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("output_path"),
+			"Invalid Output Path",
+			"output_path must not be empty",
+		)
+	}
+}
+
+func (d *FileContentDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *FileContentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FileContentDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fileID := data.FileID.ValueString()
+	maxBytes := int64(defaultFileContentMaxBytes)
+	if !data.MaxBytes.IsNull() {
+		maxBytes = data.MaxBytes.ValueInt64()
+	}
+
+	file, err := d.client.GetFile(ctx, fileID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading File",
+			fmt.Sprintf("Unable to read file %s: %s", fileID, d.client.HandleError(err)),
+		)
+		return
+	}
+
+	// Refuse before downloading when the reported size is already too large
+	if int64(file.Bytes) > maxBytes {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_bytes"),
+			"File Too Large",
+			fmt.Sprintf("File %s is %d bytes, which exceeds max_bytes (%d).", fileID, file.Bytes, maxBytes),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Downloading file content", map[string]interface{}{
+		"file_id": fileID,
+		"bytes":   file.Bytes,
+	})
+
+	buf := &cappedBuffer{max: maxBytes}
+	if _, err := d.client.DownloadFile(ctx, fileID, buf); err != nil {
+		if errors.Is(err, errFileContentTooLarge) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_bytes"),
+				"File Too Large",
+				fmt.Sprintf("The content of file %s exceeds max_bytes (%d).", fileID, maxBytes),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Downloading File",
+			fmt.Sprintf("Unable to download file %s: %s", fileID, d.client.HandleError(err)),
+		)
+		return
+	}
+	content := buf.Bytes()
+
+	if !data.OutputPath.IsNull() {
+		if err := fsutil.WriteFileAtomic(data.OutputPath.ValueString(), content); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("output_path"),
+				"Error Writing File Content",
+				fmt.Sprintf("Unable to write %s: %s", data.OutputPath.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	data.ID = types.StringValue(file.ID)
+	data.Filename = types.StringValue(file.FileName)
+	data.Bytes = types.Int64Value(int64(len(content)))
+	data.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content))
+	data.SHA256 = types.StringValue(fsutil.SHA256Hex(content))
+	if utf8.Valid(content) {
+		data.Content = types.StringValue(string(content))
+	} else {
+		data.Content = types.StringNull()
+	}
+
+	// Save into state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// cappedBuffer collects written bytes and fails once more than max are written
+type cappedBuffer struct {
+	bytes.Buffer
+	max int64
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if int64(b.Len()+len(p)) > b.max {
+		return 0, errFileContentTooLarge
+	}
+	return b.Buffer.Write(p)
+}
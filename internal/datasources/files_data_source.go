@@ -0,0 +1,237 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &FilesDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &FilesDataSource{}
+
+func NewFilesDataSource() datasource.DataSource {
+	return &FilesDataSource{}
+}
+
+// FilesDataSource defines the data source implementation.
+type FilesDataSource struct {
+	client *client.Client
+}
+
+// FilesDataSourceModel describes the data source data model.
+type FilesDataSourceModel struct {
+	ID            types.String    `tfsdk:"id"`
+	Purpose       types.String    `tfsdk:"purpose"`
+	FilenameRegex types.String    `tfsdk:"filename_regex"`
+	CreatedAfter  types.Int64     `tfsdk:"created_after"`
+	CreatedBefore types.Int64     `tfsdk:"created_before"`
+	Status        types.String    `tfsdk:"status"`
+	Files         []FileDataModel `tfsdk:"files"`
+}
+
+// FileDataModel describes a single uploaded file.
+type FileDataModel struct {
+	ID        types.String `tfsdk:"id"`
+	Filename  types.String `tfsdk:"filename"`
+	Bytes     types.Int64  `tfsdk:"bytes"`
+	Purpose   types.String `tfsdk:"purpose"`
+	Status    types.String `tfsdk:"status"`
+	CreatedAt types.Int64  `tfsdk:"created_at"`
+}
+
+func (d *FilesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_files"
+}
+
+func (d *FilesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to list the files uploaded to OpenAI.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "A static identifier for this data source.",
+				Computed:            true,
+			},
+			"purpose": schema.StringAttribute{
+				MarkdownDescription: "Only return files uploaded for this purpose, such as `assistants`, `batch` or `fine-tune`.",
+				Optional:            true,
+			},
+			"filename_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return files whose filename matches this regular expression.",
+				Optional:            true,
+			},
+			"created_after": schema.Int64Attribute{
+				MarkdownDescription: "Only return files created at or after this Unix timestamp (in seconds).",
+				Optional:            true,
+			},
+			"created_before": schema.Int64Attribute{
+				MarkdownDescription: "Only return files created before this Unix timestamp (in seconds).",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only return files with this status, one of `uploaded`, `processed` or `error`.",
+				Optional:            true,
+			},
+			"files": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching files, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the file.",
+							Computed:            true,
+						},
+						"filename": schema.StringAttribute{
+							MarkdownDescription: "The name of the file.",
+							Computed:            true,
+						},
+						"bytes": schema.Int64Attribute{
+							MarkdownDescription: "The size of the file in bytes.",
+							Computed:            true,
+						},
+						"purpose": schema.StringAttribute{
+							MarkdownDescription: "The purpose of the file.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the file.",
+							Computed:            true,
+						},
+						"created_at": schema.Int64Attribute{
+							MarkdownDescription: "The Unix timestamp (in seconds) when the file was created.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *FilesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data FilesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.FilenameRegex.IsNull() && !data.FilenameRegex.IsUnknown() {
+		if _, err := regexp.Compile(data.FilenameRegex.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("filename_regex"),
+				"Invalid Filename Regex",
+				fmt.Sprintf("filename_regex is not a valid regular expression: %s", err),
+			)
+		}
+	}
+
+	if !data.Status.IsNull() && !data.Status.IsUnknown() {
+		switch data.Status.ValueString() {
+		case "uploaded", "processed", "error":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("status"),
+				"Invalid Status",
+				fmt.Sprintf("status must be one of uploaded, processed or error, got %q", data.Status.ValueString()),
+			)
+		}
+	}
+
+	if !data.CreatedAfter.IsNull() && !data.CreatedAfter.IsUnknown() &&
+		!data.CreatedBefore.IsNull() && !data.CreatedBefore.IsUnknown() &&
+		data.CreatedAfter.ValueInt64() >= data.CreatedBefore.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("created_before"),
+			"Invalid Creation Range",
+			fmt.Sprintf("created_before (%d) must be later than created_after (%d)", data.CreatedBefore.ValueInt64(), data.CreatedAfter.ValueInt64()),
+		)
+	}
+}
+
+func (d *FilesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *FilesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FilesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filenameRegex *regexp.Regexp
+	if !data.FilenameRegex.IsNull() {
+		re, err := regexp.Compile(data.FilenameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("filename_regex"),
+				"Invalid Filename Regex",
+				fmt.Sprintf("filename_regex is not a valid regular expression: %s", err),
+			)
+			return
+		}
+		filenameRegex = re
+	}
+
+	// The API can only filter by purpose, the other filters are applied here
+	files, err := d.client.ListFiles(ctx, client.ListFilesOptions{
+		Purpose: data.Purpose.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Files",
+			fmt.Sprintf("Unable to list files: %s", d.client.HandleError(err)),
+		)
+		return
+	}
+
+	data.Files = []FileDataModel{}
+	for _, file := range files {
+		if filenameRegex != nil && !filenameRegex.MatchString(file.FileName) {
+			continue
+		}
+		if !data.CreatedAfter.IsNull() && file.CreatedAt < data.CreatedAfter.ValueInt64() {
+			continue
+		}
+		if !data.CreatedBefore.IsNull() && file.CreatedAt >= data.CreatedBefore.ValueInt64() {
+			continue
+		}
+		if !data.Status.IsNull() && file.Status != data.Status.ValueString() {
+			continue
+		}
+
+		data.Files = append(data.Files, FileDataModel{
+			ID:        types.StringValue(file.ID),
+			Filename:  types.StringValue(file.FileName),
+			Bytes:     types.Int64Value(int64(file.Bytes)),
+			Purpose:   types.StringValue(file.Purpose),
+			Status:    types.StringValue(file.Status),
+			CreatedAt: types.Int64Value(file.CreatedAt),
+		})
+	}
+
+	data.ID = types.StringValue("files")
+
+	// Save into state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,353 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ThreadMessagesDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &ThreadMessagesDataSource{}
+
+func NewThreadMessagesDataSource() datasource.DataSource {
+	return &ThreadMessagesDataSource{}
+}
+
+// ThreadMessagesDataSource defines the data source implementation.
+type ThreadMessagesDataSource struct {
+	client *client.Client
+}
+
+// ThreadMessagesDataSourceModel describes the data source data model.
+type ThreadMessagesDataSourceModel struct {
+	ID       types.String             `tfsdk:"id"`
+	ThreadID types.String             `tfsdk:"thread_id"`
+	Order    types.String             `tfsdk:"order"`
+	Limit    types.Int64              `tfsdk:"limit"`
+	RunID    types.String             `tfsdk:"run_id"`
+	Role     types.String             `tfsdk:"role"`
+	Messages []ThreadMessageDataModel `tfsdk:"messages"`
+}
+
+// ThreadMessageDataModel describes a single message in a thread.
+type ThreadMessageDataModel struct {
+	ID          types.String              `tfsdk:"id"`
+	Role        types.String              `tfsdk:"role"`
+	CreatedAt   types.Int64               `tfsdk:"created_at"`
+	RunID       types.String              `tfsdk:"run_id"`
+	AssistantID types.String              `tfsdk:"assistant_id"`
+	Text        types.String              `tfsdk:"text"`
+	TextParts   []types.String            `tfsdk:"text_parts"`
+	Annotations []ThreadMessageAnnotation `tfsdk:"annotations"`
+	Attachments []ThreadMessageAttachment `tfsdk:"attachments"`
+	Metadata    map[string]types.String   `tfsdk:"metadata"`
+}
+
+// ThreadMessageAnnotation describes a citation or file path annotation in a message.
+type ThreadMessageAnnotation struct {
+	Type       types.String `tfsdk:"type"`
+	Text       types.String `tfsdk:"text"`
+	StartIndex types.Int64  `tfsdk:"start_index"`
+	EndIndex   types.Int64  `tfsdk:"end_index"`
+	FileID     types.String `tfsdk:"file_id"`
+}
+
+// ThreadMessageAttachment describes a file attached to a message.
+type ThreadMessageAttachment struct {
+	FileID types.String   `tfsdk:"file_id"`
+	Tools  []types.String `tfsdk:"tools"`
+}
+
+func (d *ThreadMessagesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_thread_messages"
+}
+
+func (d *ThreadMessagesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to read the messages of an OpenAI thread.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the thread.",
+				Computed:            true,
+			},
+			"thread_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the thread to read messages from.",
+				Required:            true,
+			},
+			"order": schema.StringAttribute{
+				MarkdownDescription: "Sort order by creation time, either `asc` or `desc`. Defaults to `asc`.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of messages to return. By default every message is returned.",
+				Optional:            true,
+			},
+			"run_id": schema.StringAttribute{
+				MarkdownDescription: "Only return messages created by this run.",
+				Optional:            true,
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Only return messages with this role, either `user` or `assistant`.",
+				Optional:            true,
+			},
+			"messages": schema.ListNestedAttribute{
+				MarkdownDescription: "The messages in the thread.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the message.",
+							Computed:            true,
+						},
+						"role": schema.StringAttribute{
+							MarkdownDescription: "The role of the message author.",
+							Computed:            true,
+						},
+						"created_at": schema.Int64Attribute{
+							MarkdownDescription: "The Unix timestamp (in seconds) when the message was created.",
+							Computed:            true,
+						},
+						"run_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the run that created the message, if any.",
+							Computed:            true,
+						},
+						"assistant_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the assistant that authored the message, if any.",
+							Computed:            true,
+						},
+						"text": schema.StringAttribute{
+							MarkdownDescription: "The text parts of the message joined by blank lines.",
+							Computed:            true,
+						},
+						"text_parts": schema.ListAttribute{
+							MarkdownDescription: "The text of each text content part of the message.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"annotations": schema.ListNestedAttribute{
+							MarkdownDescription: "The annotations in the message's text parts.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										MarkdownDescription: "The type of annotation, either file_citation or file_path.",
+										Computed:            true,
+									},
+									"text": schema.StringAttribute{
+										MarkdownDescription: "The text in the message content that is replaced by the annotation.",
+										Computed:            true,
+									},
+									"start_index": schema.Int64Attribute{
+										MarkdownDescription: "The start index of the annotation within the text.",
+										Computed:            true,
+									},
+									"end_index": schema.Int64Attribute{
+										MarkdownDescription: "The end index of the annotation within the text.",
+										Computed:            true,
+									},
+									"file_id": schema.StringAttribute{
+										MarkdownDescription: "The ID of the file the annotation refers to.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"attachments": schema.ListNestedAttribute{
+							MarkdownDescription: "The files attached to the message.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"file_id": schema.StringAttribute{
+										MarkdownDescription: "The ID of the attached file.",
+										Computed:            true,
+									},
+									"tools": schema.ListAttribute{
+										MarkdownDescription: "The tools the file was added to.",
+										Computed:            true,
+										ElementType:         types.StringType,
+									},
+								},
+							},
+						},
+						"metadata": schema.MapAttribute{
+							MarkdownDescription: "Metadata key-value pairs for the message.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ThreadMessagesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data ThreadMessagesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Order.IsNull() && !data.Order.IsUnknown() {
+		switch data.Order.ValueString() {
+		case "asc", "desc":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("order"),
+				"Invalid Order",
+				fmt.Sprintf("order must be either asc or desc, got %q", data.Order.ValueString()),
+			)
+		}
+	}
+
+	if !data.Limit.IsNull() && !data.Limit.IsUnknown() && data.Limit.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("limit"),
+			"Invalid Limit",
+			fmt.Sprintf("limit must be at least 1, got %d", data.Limit.ValueInt64()),
+		)
+	}
+
+	if !data.Role.IsNull() && !data.Role.IsUnknown() {
+		switch data.Role.ValueString() {
+		case "user", "assistant":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("role"),
+				"Invalid Role",
+				fmt.Sprintf("role must be either user or assistant, got %q", data.Role.ValueString()),
+			)
+		}
+	}
+}
+
+func (d *ThreadMessagesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ThreadMessagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ThreadMessagesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := client.ListMessagesOptions{
+		Order: data.Order.ValueString(),
+		RunID: data.RunID.ValueString(),
+	}
+
+	// The API cannot filter by role, so the limit is applied after filtering
+	limit := int(data.Limit.ValueInt64())
+	role := data.Role.ValueString()
+	if role == "" {
+		opts.Limit = limit
+	}
+
+	messages, err := d.client.ListMessages(ctx, data.ThreadID.ValueString(), opts)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Thread Messages",
+			fmt.Sprintf("Unable to list messages in thread %s: %s", data.ThreadID.ValueString(), d.client.HandleError(err)),
+		)
+		return
+	}
+
+	data.Messages = []ThreadMessageDataModel{}
+	for _, msg := range messages {
+		if role != "" && msg.Role != role {
+			continue
+		}
+		if limit > 0 && len(data.Messages) >= limit {
+			break
+		}
+		data.Messages = append(data.Messages, flattenThreadMessage(msg))
+	}
+
+	data.ID = data.ThreadID
+
+	// Save into state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// flattenThreadMessage converts an API message into the data source model
+func flattenThreadMessage(msg client.Message) ThreadMessageDataModel {
+	model := ThreadMessageDataModel{
+		ID:          types.StringValue(msg.ID),
+		Role:        types.StringValue(msg.Role),
+		CreatedAt:   types.Int64Value(msg.CreatedAt),
+		RunID:       types.StringValue(""),
+		AssistantID: types.StringValue(""),
+		TextParts:   []types.String{},
+		Annotations: []ThreadMessageAnnotation{},
+		Attachments: []ThreadMessageAttachment{},
+		Metadata:    map[string]types.String{},
+	}
+
+	if msg.RunID != nil {
+		model.RunID = types.StringValue(*msg.RunID)
+	}
+	if msg.AssistantID != nil {
+		model.AssistantID = types.StringValue(*msg.AssistantID)
+	}
+
+	text := ""
+	for _, content := range msg.Content {
+		if content.Type != "text" || content.Text == nil {
+			continue
+		}
+		if len(model.TextParts) > 0 {
+			text += "\n\n"
+		}
+		text += content.Text.Value
+		model.TextParts = append(model.TextParts, types.StringValue(content.Text.Value))
+
+		for _, annotation := range content.Text.Annotations {
+			model.Annotations = append(model.Annotations, ThreadMessageAnnotation{
+				Type:       types.StringValue(annotation.Type),
+				Text:       types.StringValue(annotation.Text),
+				StartIndex: types.Int64Value(int64(annotation.StartIndex)),
+				EndIndex:   types.Int64Value(int64(annotation.EndIndex)),
+				FileID:     types.StringValue(annotation.FileID()),
+			})
+		}
+	}
+	model.Text = types.StringValue(text)
+
+	for _, attachment := range msg.Attachments {
+		tools := []types.String{}
+		for _, tool := range attachment.Tools {
+			tools = append(tools, types.StringValue(tool.Type))
+		}
+		model.Attachments = append(model.Attachments, ThreadMessageAttachment{
+			FileID: types.StringValue(attachment.FileID),
+			Tools:  tools,
+		})
+	}
+
+	for k, v := range msg.Metadata {
+		model.Metadata[k] = types.StringValue(fmt.Sprintf("%v", v))
+	}
+
+	return model
+}
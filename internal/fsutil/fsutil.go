@@ -0,0 +1,55 @@
+// Package fsutil contains helpers for the local files written by resources
+// and data sources.
+package fsutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// WriteAtomic creates the parent directories of outputPath, passes a
+// temporary file next to it to write and renames the file into place, so
+// readers never see a partial file. The temporary file is removed if write
+// fails.
+func WriteAtomic(outputPath string, write func(w io.Writer) error) error {
+	dir := filepath.Dir(outputPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(outputPath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), outputPath)
+}
+
+// WriteFileAtomic writes content to outputPath with WriteAtomic
+func WriteFileAtomic(outputPath string, content []byte) error {
+	return WriteAtomic(outputPath, func(w io.Writer) error {
+		_, err := w.Write(content)
+		return err
+	})
+}
+
+// SHA256Hex returns the hex-encoded SHA-256 checksum of content
+func SHA256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
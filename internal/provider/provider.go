@@ -137,6 +137,9 @@ func (p *OpenAIProvider) DataSources(_ context.Context) []func() datasource.Data
 		datasources.NewAssistantDataSource,
 		datasources.NewChatCompletionDataSource,
 		datasources.NewVectorStoreDataSource,
+		datasources.NewThreadMessagesDataSource,
+		datasources.NewFilesDataSource,
+		datasources.NewFileContentDataSource,
 	}
 }
 
@@ -151,7 +154,10 @@ func (p *OpenAIProvider) Resources(_ context.Context) []func() resource.Resource
 		resources.NewMessageResource,
 		resources.NewRunResource,
 		resources.NewThreadResource,
+		resources.NewThreadRunResource,
+		resources.NewThreadTranscriptResource,
 		resources.NewVectorStoreResource,
 		resources.NewVectorStoreFileResource,
+		resources.NewVectorStoreFileBatchResource,
 	}
 }
@@ -0,0 +1,164 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	chunkingStrategyAuto   = "auto"
+	chunkingStrategyStatic = "static"
+
+	minChunkSizeTokens = 100
+	maxChunkSizeTokens = 4096
+)
+
+// ChunkingStrategyModel describes how files are split into chunks.
+type ChunkingStrategyModel struct {
+	Type               types.String `tfsdk:"type"`
+	MaxChunkSizeTokens types.Int64  `tfsdk:"max_chunk_size_tokens"`
+	ChunkOverlapTokens types.Int64  `tfsdk:"chunk_overlap_tokens"`
+}
+
+// chunkingStrategySchemaBlock returns the chunking_strategy block shared by
+// the vector store resources. Files are only chunked once, so changing the
+// strategy forces a new resource.
+func chunkingStrategySchemaBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: description,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The chunking strategy, either `auto` or `static`.",
+				Required:            true,
+			},
+			"max_chunk_size_tokens": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of tokens in each chunk, between 100 and 4096. Required for `static`.",
+				Optional:            true,
+			},
+			"chunk_overlap_tokens": schema.Int64Attribute{
+				MarkdownDescription: "The number of tokens that overlap between chunks. Must not exceed half of `max_chunk_size_tokens`. Required for `static`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// validateChunkingStrategy checks a configured chunking strategy. Unknown
+// values are skipped so they can be checked once they are known.
+func validateChunkingStrategy(strategy *ChunkingStrategyModel, attrPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if strategy == nil || strategy.Type.IsUnknown() {
+		return diags
+	}
+
+	switch strategy.Type.ValueString() {
+	case chunkingStrategyAuto:
+		for name, value := range map[string]types.Int64{
+			"max_chunk_size_tokens": strategy.MaxChunkSizeTokens,
+			"chunk_overlap_tokens":  strategy.ChunkOverlapTokens,
+		} {
+			if !value.IsNull() {
+				diags.AddAttributeError(
+					attrPath.AtName(name),
+					"Invalid Chunking Strategy",
+					fmt.Sprintf("%s can only be set when type is %q", name, chunkingStrategyStatic),
+				)
+			}
+		}
+	case chunkingStrategyStatic:
+		size, overlap := strategy.MaxChunkSizeTokens, strategy.ChunkOverlapTokens
+		if size.IsNull() || overlap.IsNull() {
+			diags.AddAttributeError(
+				attrPath,
+				"Invalid Chunking Strategy",
+				fmt.Sprintf("max_chunk_size_tokens and chunk_overlap_tokens are required when type is %q", chunkingStrategyStatic),
+			)
+			return diags
+		}
+		if !size.IsUnknown() && (size.ValueInt64() < minChunkSizeTokens || size.ValueInt64() > maxChunkSizeTokens) {
+			diags.AddAttributeError(
+				attrPath.AtName("max_chunk_size_tokens"),
+				"Invalid Chunking Strategy",
+				fmt.Sprintf("max_chunk_size_tokens must be between %d and %d, got %d", minChunkSizeTokens, maxChunkSizeTokens, size.ValueInt64()),
+			)
+		}
+		if !overlap.IsUnknown() && overlap.ValueInt64() < 0 {
+			diags.AddAttributeError(
+				attrPath.AtName("chunk_overlap_tokens"),
+				"Invalid Chunking Strategy",
+				fmt.Sprintf("chunk_overlap_tokens must not be negative, got %d", overlap.ValueInt64()),
+			)
+		}
+		if !size.IsUnknown() && !overlap.IsUnknown() && overlap.ValueInt64()*2 > size.ValueInt64() {
+			diags.AddAttributeError(
+				attrPath.AtName("chunk_overlap_tokens"),
+				"Invalid Chunking Strategy",
+				fmt.Sprintf("chunk_overlap_tokens (%d) must not exceed half of max_chunk_size_tokens (%d)", overlap.ValueInt64(), size.ValueInt64()),
+			)
+		}
+	default:
+		diags.AddAttributeError(
+			attrPath.AtName("type"),
+			"Invalid Chunking Strategy",
+			fmt.Sprintf("type must be %q or %q, got %q", chunkingStrategyAuto, chunkingStrategyStatic, strategy.Type.ValueString()),
+		)
+	}
+
+	return diags
+}
+
+// expandChunkingStrategy converts a configured chunking strategy into its API
+// form. A nil strategy leaves the choice to the API.
+func expandChunkingStrategy(strategy *ChunkingStrategyModel) *client.ChunkingStrategy {
+	if strategy == nil {
+		return nil
+	}
+
+	result := &client.ChunkingStrategy{Type: strategy.Type.ValueString()}
+	if result.Type == chunkingStrategyStatic {
+		result.Static = &client.StaticChunkingStrategy{
+			MaxChunkSizeTokens: strategy.MaxChunkSizeTokens.ValueInt64(),
+			ChunkOverlapTokens: strategy.ChunkOverlapTokens.ValueInt64(),
+		}
+	}
+	return result
+}
+
+// flattenChunkingStrategy reflects the strategy reported by the API onto the
+// one in state. The API reports auto chunking as the static strategy it
+// resolved to, so an auto strategy in state is kept as is, and a strategy
+// that was never configured stays unset.
+func flattenChunkingStrategy(current *ChunkingStrategyModel, reported *client.ChunkingStrategy) *ChunkingStrategyModel {
+	if current == nil || reported == nil || current.Type.ValueString() == chunkingStrategyAuto {
+		return current
+	}
+
+	result := &ChunkingStrategyModel{
+		Type:               types.StringValue(reported.Type),
+		MaxChunkSizeTokens: types.Int64Null(),
+		ChunkOverlapTokens: types.Int64Null(),
+	}
+	if reported.Static != nil {
+		result.MaxChunkSizeTokens = types.Int64Value(reported.Static.MaxChunkSizeTokens)
+		result.ChunkOverlapTokens = types.Int64Value(reported.Static.ChunkOverlapTokens)
+	}
+	return result
+}
+
+// chunkingStrategyEqual reports whether two chunking strategies are the same
+func chunkingStrategyEqual(a, b *ChunkingStrategyModel) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Type.Equal(b.Type) && a.MaxChunkSizeTokens.Equal(b.MaxChunkSizeTokens) && a.ChunkOverlapTokens.Equal(b.ChunkOverlapTokens)
+}
@@ -0,0 +1,217 @@
+package resources
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// diagnosticPaths returns the attribute paths of the errors in diags, sorted
+func diagnosticPaths(diags diag.Diagnostics) []string {
+	var paths []string
+	for _, d := range diags.Errors() {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			paths = append(paths, withPath.Path().String())
+		} else {
+			paths = append(paths, "")
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func staticChunkingStrategy(size, overlap int64) *ChunkingStrategyModel {
+	return &ChunkingStrategyModel{
+		Type:               types.StringValue(chunkingStrategyStatic),
+		MaxChunkSizeTokens: types.Int64Value(size),
+		ChunkOverlapTokens: types.Int64Value(overlap),
+	}
+}
+
+func TestValidateChunkingStrategy(t *testing.T) {
+	root := path.Root("chunking_strategy")
+
+	tests := []struct {
+		name     string
+		strategy *ChunkingStrategyModel
+		want     []string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name: "unknown type",
+			strategy: &ChunkingStrategyModel{
+				Type:               types.StringUnknown(),
+				MaxChunkSizeTokens: types.Int64Value(1),
+				ChunkOverlapTokens: types.Int64Null(),
+			},
+		},
+		{
+			name: "auto",
+			strategy: &ChunkingStrategyModel{
+				Type:               types.StringValue(chunkingStrategyAuto),
+				MaxChunkSizeTokens: types.Int64Null(),
+				ChunkOverlapTokens: types.Int64Null(),
+			},
+		},
+		{
+			name: "auto with sizes",
+			strategy: &ChunkingStrategyModel{
+				Type:               types.StringValue(chunkingStrategyAuto),
+				MaxChunkSizeTokens: types.Int64Value(800),
+				ChunkOverlapTokens: types.Int64Value(400),
+			},
+			want: []string{"chunking_strategy.chunk_overlap_tokens", "chunking_strategy.max_chunk_size_tokens"},
+		},
+		{
+			name:     "static",
+			strategy: staticChunkingStrategy(800, 400),
+		},
+		{
+			name:     "static at the limits",
+			strategy: staticChunkingStrategy(maxChunkSizeTokens, 0),
+		},
+		{
+			name: "static without sizes",
+			strategy: &ChunkingStrategyModel{
+				Type:               types.StringValue(chunkingStrategyStatic),
+				MaxChunkSizeTokens: types.Int64Value(800),
+				ChunkOverlapTokens: types.Int64Null(),
+			},
+			want: []string{"chunking_strategy"},
+		},
+		{
+			name:     "static chunk size too small",
+			strategy: staticChunkingStrategy(minChunkSizeTokens-1, 0),
+			want:     []string{"chunking_strategy.max_chunk_size_tokens"},
+		},
+		{
+			name:     "static chunk size too large",
+			strategy: staticChunkingStrategy(maxChunkSizeTokens+1, 0),
+			want:     []string{"chunking_strategy.max_chunk_size_tokens"},
+		},
+		{
+			name:     "static negative overlap",
+			strategy: staticChunkingStrategy(800, -1),
+			want:     []string{"chunking_strategy.chunk_overlap_tokens"},
+		},
+		{
+			name:     "static overlap over half",
+			strategy: staticChunkingStrategy(800, 401),
+			want:     []string{"chunking_strategy.chunk_overlap_tokens"},
+		},
+		{
+			name: "static unknown sizes",
+			strategy: &ChunkingStrategyModel{
+				Type:               types.StringValue(chunkingStrategyStatic),
+				MaxChunkSizeTokens: types.Int64Unknown(),
+				ChunkOverlapTokens: types.Int64Unknown(),
+			},
+		},
+		{
+			name: "unsupported type",
+			strategy: &ChunkingStrategyModel{
+				Type:               types.StringValue("semantic"),
+				MaxChunkSizeTokens: types.Int64Null(),
+				ChunkOverlapTokens: types.Int64Null(),
+			},
+			want: []string{"chunking_strategy.type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diagnosticPaths(validateChunkingStrategy(tt.strategy, root))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got errors at %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got errors at %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFlattenChunkingStrategy(t *testing.T) {
+	auto := &ChunkingStrategyModel{
+		Type:               types.StringValue(chunkingStrategyAuto),
+		MaxChunkSizeTokens: types.Int64Null(),
+		ChunkOverlapTokens: types.Int64Null(),
+	}
+	reported := &client.ChunkingStrategy{
+		Type:   chunkingStrategyStatic,
+		Static: &client.StaticChunkingStrategy{MaxChunkSizeTokens: 1200, ChunkOverlapTokens: 300},
+	}
+
+	tests := []struct {
+		name     string
+		current  *ChunkingStrategyModel
+		reported *client.ChunkingStrategy
+		want     *ChunkingStrategyModel
+	}{
+		{
+			name:     "never configured",
+			reported: reported,
+		},
+		{
+			name:    "not reported",
+			current: staticChunkingStrategy(800, 400),
+			want:    staticChunkingStrategy(800, 400),
+		},
+		{
+			name:     "auto resolved to static",
+			current:  auto,
+			reported: reported,
+			want:     auto,
+		},
+		{
+			name:     "static unchanged",
+			current:  staticChunkingStrategy(1200, 300),
+			reported: reported,
+			want:     staticChunkingStrategy(1200, 300),
+		},
+		{
+			name:     "static drifted",
+			current:  staticChunkingStrategy(800, 400),
+			reported: reported,
+			want:     staticChunkingStrategy(1200, 300),
+		},
+		{
+			name:     "other type",
+			current:  staticChunkingStrategy(800, 400),
+			reported: &client.ChunkingStrategy{Type: "other"},
+			want: &ChunkingStrategyModel{
+				Type:               types.StringValue("other"),
+				MaxChunkSizeTokens: types.Int64Null(),
+				ChunkOverlapTokens: types.Int64Null(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenChunkingStrategy(tt.current, tt.reported)
+			if !chunkingStrategyEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandChunkingStrategy(t *testing.T) {
+	if got := expandChunkingStrategy(nil); got != nil {
+		t.Fatalf("got %+v for an unset strategy, want nil", got)
+	}
+
+	got := expandChunkingStrategy(staticChunkingStrategy(800, 400))
+	if got.Type != chunkingStrategyStatic || got.Static == nil || got.Static.MaxChunkSizeTokens != 800 || got.Static.ChunkOverlapTokens != 400 {
+		t.Fatalf("got %+v for a static strategy", got)
+	}
+}
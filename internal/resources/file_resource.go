@@ -1,14 +1,27 @@
 package resources
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/darnold/terraform-provider-openai/internal/fsutil"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -19,6 +32,24 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &FileResource{}
 var _ resource.ResourceWithImportState = &FileResource{}
+var _ resource.ResourceWithValidateConfig = &FileResource{}
+
+const (
+	// defaultMultipartPartSize is the part size, and the size above which
+	// sources are uploaded in parts, when multipart_part_size is not set
+	defaultMultipartPartSize = client.UploadMaxPartSize
+	// minMultipartPartSize keeps large files from being split into too many parts
+	minMultipartPartSize = 1024 * 1024
+	// defaultFileProcessingTimeout bounds wait_until_processed when processing_timeout is not set
+	defaultFileProcessingTimeout = 10 * time.Minute
+	// fileProcessingPollInterval is how often the file status is checked while waiting
+	fileProcessingPollInterval = 2 * time.Second
+	// fileExpiresAfterAnchor is the only timestamp file expiry can be measured from
+	fileExpiresAfterAnchor = "created_at"
+	// minFileExpiresAfterSeconds and maxFileExpiresAfterSeconds bound expires_after.seconds
+	minFileExpiresAfterSeconds = 3600
+	maxFileExpiresAfterSeconds = 30 * 24 * 3600
+)
 
 func NewFileResource() resource.Resource {
 	return &FileResource{}
@@ -31,16 +62,31 @@ type FileResource struct {
 
 // FileResourceModel describes the resource data model.
 type FileResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Filename      types.String `tfsdk:"filename"`
-	FilePath      types.String `tfsdk:"file_path"`
-	Content       types.String `tfsdk:"content"`
-	Purpose       types.String `tfsdk:"purpose"`
-	ObjectID      types.String `tfsdk:"object_id"`
-	Bytes         types.Int64  `tfsdk:"bytes"`
-	CreatedAt     types.Int64  `tfsdk:"created_at"`
-	Status        types.String `tfsdk:"status"`
-	StatusDetails types.String `tfsdk:"status_details"`
+	ID                 types.String `tfsdk:"id"`
+	Filename           types.String `tfsdk:"filename"`
+	FilePath           types.String `tfsdk:"file_path"`
+	Content            types.String `tfsdk:"content"`
+	ContentBase64      types.String `tfsdk:"content_base64"`
+	Purpose            types.String `tfsdk:"purpose"`
+	ObjectID           types.String `tfsdk:"object_id"`
+	Bytes              types.Int64  `tfsdk:"bytes"`
+	CreatedAt          types.Int64  `tfsdk:"created_at"`
+	Status             types.String `tfsdk:"status"`
+	StatusDetails      types.String `tfsdk:"status_details"`
+	SourceHash         types.String `tfsdk:"source_hash"`
+	RemoteFilename     types.String `tfsdk:"remote_filename"`
+	MultipartPartSize  types.Int64  `tfsdk:"multipart_part_size"`
+	WaitUntilProcessed types.Bool   `tfsdk:"wait_until_processed"`
+	ProcessingTimeout  types.String `tfsdk:"processing_timeout"`
+	ExpiresAt          types.Int64  `tfsdk:"expires_at"`
+
+	ExpiresAfter *FileExpiresAfterModel `tfsdk:"expires_after"`
+}
+
+// FileExpiresAfterModel describes the expiration policy of an uploaded file.
+type FileExpiresAfterModel struct {
+	Anchor  types.String `tfsdk:"anchor"`
+	Seconds types.Int64  `tfsdk:"seconds"`
 }
 
 func (r *FileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -51,6 +97,25 @@ func (r *FileResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Upload and manage files on the OpenAI platform for fine-tuning or use with assistants.",
 
+		Blocks: map[string]schema.Block{
+			"expires_after": schema.SingleNestedBlock{
+				MarkdownDescription: "Expiration policy for the uploaded file. The file is deleted by OpenAI once it expires. Changing this uploads the file again.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"anchor": schema.StringAttribute{
+						MarkdownDescription: "The timestamp the expiry is measured from. Only `created_at` is supported. Defaults to `created_at`.",
+						Optional:            true,
+					},
+					"seconds": schema.Int64Attribute{
+						MarkdownDescription: "The number of seconds after the anchor when the file expires, between 3600 (1 hour) and 2592000 (30 days).",
+						Required:            true,
+					},
+				},
+			},
+		},
+
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -64,11 +129,16 @@ func (r *FileResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Required:            true,
 			},
 			"file_path": schema.StringAttribute{
-				MarkdownDescription: "The local path to the file to upload. Either file_path or content must be specified.",
+				MarkdownDescription: "The local path to the file to upload. Exactly one of file_path, content or content_base64 must be specified.",
 				Optional:            true,
 			},
 			"content": schema.StringAttribute{
-				MarkdownDescription: "The string content to upload as a file. Either file_path or content must be specified.",
+				MarkdownDescription: "The string content to upload as a file. Exactly one of file_path, content or content_base64 must be specified.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"content_base64": schema.StringAttribute{
+				MarkdownDescription: "Base64-encoded content to upload as a file, for binary data such as PDFs, images or compressed files. Exactly one of file_path, content or content_base64 must be specified.",
 				Optional:            true,
 				Sensitive:           true,
 			},
@@ -96,6 +166,33 @@ func (r *FileResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "Additional details about the file's status, particularly useful for errors.",
 				Computed:            true,
 			},
+			"source_hash": schema.StringAttribute{
+				MarkdownDescription: "The hex-encoded SHA-256 checksum of the uploaded bytes, read from file_path or content. A change forces a new upload.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					sourceHashModifier{},
+				},
+			},
+			"remote_filename": schema.StringAttribute{
+				MarkdownDescription: "The filename recorded by the OpenAI API for the upload.",
+				Computed:            true,
+			},
+			"multipart_part_size": schema.Int64Attribute{
+				MarkdownDescription: "The part size in bytes for multipart uploads. Sources larger than this are sent in parts through the Uploads API, with several parts in flight and each part retried on failure. Must be between 1 MiB and 64 MiB. Defaults to 64 MiB.",
+				Optional:            true,
+			},
+			"wait_until_processed": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait until the file has been processed before marking the resource as created. Processing errors fail the apply with the file's status_details. Defaults to false.",
+				Optional:            true,
+			},
+			"processing_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait for processing when wait_until_processed is true. Defaults to 10m.",
+				Optional:            true,
+			},
+			"expires_at": schema.Int64Attribute{
+				MarkdownDescription: "The Unix timestamp (in seconds) for when the file expires, if it has an expiration policy.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -117,73 +214,91 @@ func (r *FileResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
-func (r *FileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan FileResourceModel
-
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
+func (r *FileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data FileResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Validate that either file_path or content is provided
-	if plan.FilePath.IsNull() && plan.Content.IsNull() {
-		resp.Diagnostics.AddError(
-			"Missing File Source",
-			"Either file_path or content must be specified",
-		)
-		return
+	if !data.MultipartPartSize.IsNull() && !data.MultipartPartSize.IsUnknown() {
+		if size := data.MultipartPartSize.ValueInt64(); size < minMultipartPartSize || size > client.UploadMaxPartSize {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("multipart_part_size"),
+				"Invalid Multipart Part Size",
+				fmt.Sprintf("multipart_part_size must be between %d and %d bytes, got %d", minMultipartPartSize, client.UploadMaxPartSize, size),
+			)
+		}
 	}
 
-	if !plan.FilePath.IsNull() && !plan.Content.IsNull() {
-		resp.Diagnostics.AddError(
-			"Invalid Configuration",
-			"Only one of file_path or content can be specified",
-		)
-		return
+	if !data.ProcessingTimeout.IsNull() && !data.ProcessingTimeout.IsUnknown() {
+		if d, err := time.ParseDuration(data.ProcessingTimeout.ValueString()); err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("processing_timeout"),
+				"Invalid Processing Timeout",
+				fmt.Sprintf("processing_timeout must be a positive duration such as \"10m\", got %q", data.ProcessingTimeout.ValueString()),
+			)
+		}
 	}
 
-	var fileReq openai.FileRequest
-	var tempFile string
-	var err error
-
-	// Handle file creation based on source
-	if !plan.FilePath.IsNull() {
-		fileReq = openai.FileRequest{
-			FilePath: plan.FilePath.ValueString(),
-			Purpose:  plan.Purpose.ValueString(),
-		}
-	} else {
-		// Create a temporary file with the content
-		tempFile, err = r.createTempFile(plan.Filename.ValueString(), plan.Content.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Creating Temporary File",
-				fmt.Sprintf("Unable to create temporary file: %s", err),
+	if data.ExpiresAfter != nil {
+		if anchor := data.ExpiresAfter.Anchor; !anchor.IsNull() && !anchor.IsUnknown() && anchor.ValueString() != fileExpiresAfterAnchor {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expires_after").AtName("anchor"),
+				"Invalid Expiration Anchor",
+				fmt.Sprintf("expires_after.anchor must be %q, got %q", fileExpiresAfterAnchor, anchor.ValueString()),
 			)
-			return
 		}
-		defer os.Remove(tempFile) // Clean up temporary file
-		fileReq = openai.FileRequest{
-			FilePath: tempFile,
-			FileName: plan.Filename.ValueString(), // Set the original filename
-			Purpose:  plan.Purpose.ValueString(),
+		if seconds := data.ExpiresAfter.Seconds; !seconds.IsNull() && !seconds.IsUnknown() &&
+			(seconds.ValueInt64() < minFileExpiresAfterSeconds || seconds.ValueInt64() > maxFileExpiresAfterSeconds) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expires_after").AtName("seconds"),
+				"Invalid Expiration",
+				fmt.Sprintf("expires_after.seconds must be between %d and %d, got %d", minFileExpiresAfterSeconds, maxFileExpiresAfterSeconds, seconds.ValueInt64()),
+			)
 		}
 	}
 
+	// Sources that are not known yet are checked again at apply time
+	if data.FilePath.IsUnknown() || data.Content.IsUnknown() || data.ContentBase64.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateFileSource(data.FilePath, data.Content, data.ContentBase64)...)
+	if resp.Diagnostics.HasError() || data.Purpose.IsUnknown() || data.Filename.IsUnknown() {
+		return
+	}
+
+	// Check the content against the rules for its purpose before anything is
+	// uploaded. Only the start of large sources is read here, since this runs
+	// on every validation, and the rest is checked before the upload.
+	resp.Diagnostics.Append(validateFileSourceContent(data.Purpose.ValueString(), data.Filename.ValueString(), data.FilePath, data.Content, data.ContentBase64, planFileValidationMaxBytes)...)
+}
+
+func (r *FileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan FileResourceModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate that exactly one file source is provided
+	resp.Diagnostics.Append(validateFileSource(plan.FilePath, plan.Content, plan.ContentBase64)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, "Creating file", map[string]interface{}{
 		"filename": plan.Filename.ValueString(),
 		"purpose":  plan.Purpose.ValueString(),
 	})
 
-	// Upload the file - no need to set headers manually as they are handled by the transport
-	file, err := r.client.OpenAI.CreateFile(ctx, fileReq)
-
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating File",
-			fmt.Sprintf("Unable to create file: %s", r.client.HandleError(err)),
-		)
+	// Upload the file, in parts when it is larger than multipart_part_size
+	file, sourceSize, uploadDiags := r.uploadFile(ctx, &plan)
+	resp.Diagnostics.Append(uploadDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -196,10 +311,22 @@ func (r *FileResource) Create(ctx context.Context, req resource.CreateRequest, r
 	plan.Filename = types.StringValue(plan.Filename.ValueString())
 	plan.Purpose = types.StringValue(file.Purpose)
 	plan.Status = types.StringValue(file.Status)
-	if file.StatusDetails != "" {
-		plan.StatusDetails = types.StringValue(file.StatusDetails)
-	} else {
-		plan.StatusDetails = types.StringNull()
+	plan.StatusDetails = fileStatusDetails(file)
+	plan.RemoteFilename = types.StringValue(file.FileName)
+	plan.ExpiresAt = fileExpiresAt(file)
+
+	// The API reports the size it received, which should match the source
+	if int64(file.Bytes) != sourceSize {
+		resp.Diagnostics.AddWarning(
+			"Uploaded File Size Mismatch",
+			fmt.Sprintf("File %s was uploaded with %d bytes, but the source has %d bytes.", file.ID, file.Bytes, sourceSize),
+		)
+	}
+
+	// Wait for processing so downstream resources never see an unprocessed
+	// file. The file is saved either way so a failed file is replaced.
+	if plan.WaitUntilProcessed.ValueBool() {
+		resp.Diagnostics.Append(r.waitUntilProcessed(ctx, &plan)...)
 	}
 
 	// Save into state
@@ -210,22 +337,186 @@ func (r *FileResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 }
 
-// Helper function to create a temporary file with content
-func (r *FileResource) createTempFile(filename string, content string) (string, error) {
-	// Create temporary file
-	tmpfile, err := os.CreateTemp("", "openai-*-"+filename)
+// waitUntilProcessed polls the uploaded file until it is processed, and
+// reports an error when processing fails or does not finish in time
+func (r *FileResource) waitUntilProcessed(ctx context.Context, plan *FileResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	timeout := defaultFileProcessingTimeout
+	if !plan.ProcessingTimeout.IsNull() {
+		d, err := time.ParseDuration(plan.ProcessingTimeout.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Invalid Processing Timeout",
+				fmt.Sprintf("Unable to parse processing timeout: %s", err),
+			)
+			return diags
+		}
+		timeout = d
+	}
+
+	fileID := plan.ObjectID.ValueString()
+	deadline := time.Now().Add(timeout)
+
+	for plan.Status.ValueString() != "processed" && plan.Status.ValueString() != "error" {
+		if time.Now().After(deadline) {
+			diags.AddError(
+				"File Processing Timeout",
+				fmt.Sprintf("File %s was still %s after %s", fileID, plan.Status.ValueString(), timeout),
+			)
+			return diags
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"File Processing Interrupted",
+				fmt.Sprintf("Stopped waiting for file %s while it was %s: %s", fileID, plan.Status.ValueString(), ctx.Err()),
+			)
+			return diags
+		case <-time.After(fileProcessingPollInterval):
+		}
+
+		file, err := r.client.GetFile(ctx, fileID)
+		if err != nil {
+			diags.AddError(
+				"Error Reading File",
+				fmt.Sprintf("Unable to read file status: %s", r.client.HandleError(err)),
+			)
+			return diags
+		}
+
+		tflog.Debug(ctx, "Waiting for file processing", map[string]interface{}{
+			"file_id": fileID,
+			"status":  file.Status,
+		})
+
+		plan.Status = types.StringValue(file.Status)
+		plan.StatusDetails = fileStatusDetails(file)
+	}
+
+	if plan.Status.ValueString() == "error" {
+		diags.AddError(
+			"File Processing Failed",
+			fmt.Sprintf("File %s could not be processed: %s", fileID, plan.StatusDetails.ValueString()),
+		)
+	}
+
+	return diags
+}
+
+// uploadFile uploads the configured source and returns the created file along
+// with the size of the source. Sources larger than multipart_part_size are
+// sent in parts through the Uploads API.
+func (r *FileResource) uploadFile(ctx context.Context, plan *FileResourceModel) (*client.File, int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Hash the bytes about to be uploaded so content edits are detected
+	sourceHash, sourceSize, err := fileSourceHash(plan.FilePath, plan.Content, plan.ContentBase64)
 	if err != nil {
-		return "", fmt.Errorf("error creating temporary file: %w", err)
+		diags.AddError(
+			"Error Reading File Source",
+			fmt.Sprintf("Unable to hash file source: %s", err),
+		)
+		return nil, 0, diags
+	}
+	if !plan.SourceHash.IsUnknown() && plan.SourceHash.ValueString() != sourceHash {
+		diags.AddError(
+			"File Source Changed",
+			"The file content changed between plan and apply. Run terraform apply again to upload the current content.",
+		)
+		return nil, 0, diags
+	}
+	plan.SourceHash = types.StringValue(sourceHash)
+
+	// Files generated during apply, and all but the start of large files,
+	// could not be checked at plan time
+	diags.Append(validateFileSourceContent(plan.Purpose.ValueString(), plan.Filename.ValueString(), plan.FilePath, plan.Content, plan.ContentBase64, 0)...)
+	if diags.HasError() {
+		return nil, 0, diags
+	}
+
+	partSize := int64(defaultMultipartPartSize)
+	if !plan.MultipartPartSize.IsNull() {
+		partSize = plan.MultipartPartSize.ValueInt64()
+	}
+
+	var expiresAfter *client.FileExpiresAfter
+	if plan.ExpiresAfter != nil {
+		expiresAfter = &client.FileExpiresAfter{
+			Anchor:  fileExpiresAfterAnchor,
+			Seconds: plan.ExpiresAfter.Seconds.ValueInt64(),
+		}
+		if !plan.ExpiresAfter.Anchor.IsNull() {
+			expiresAfter.Anchor = plan.ExpiresAfter.Anchor.ValueString()
+		}
+	}
+
+	// Local files keep their own name, matching earlier versions of the
+	// provider, whichever way they are uploaded
+	filename := plan.Filename.ValueString()
+	var reader interface {
+		io.Reader
+		io.ReaderAt
+	}
+	if !plan.FilePath.IsNull() {
+		f, err := os.Open(plan.FilePath.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Error Reading File Source",
+				fmt.Sprintf("Unable to open file: %s", err),
+			)
+			return nil, 0, diags
+		}
+		defer f.Close()
+		reader = f
+		filename = filepath.Base(plan.FilePath.ValueString())
+	} else {
+		content, err := readFileSource(plan.FilePath, plan.Content, plan.ContentBase64)
+		if err != nil {
+			diags.AddError(
+				"Error Reading File Source",
+				fmt.Sprintf("Unable to read inline content: %s", err),
+			)
+			return nil, 0, diags
+		}
+		reader = bytes.NewReader(content)
 	}
-	defer tmpfile.Close()
 
-	// Write content to the file
-	if _, err := tmpfile.WriteString(content); err != nil {
-		os.Remove(tmpfile.Name()) // Clean up on error
-		return "", fmt.Errorf("error writing to temporary file: %w", err)
+	if sourceSize > partSize {
+		file, err := r.client.UploadFile(ctx, client.UploadFileRequest{
+			Filename:     filename,
+			Purpose:      plan.Purpose.ValueString(),
+			MimeType:     fileMimeType(filename),
+			Reader:       reader,
+			Size:         sourceSize,
+			PartSize:     partSize,
+			ExpiresAfter: expiresAfter,
+		})
+		if err != nil {
+			diags.AddError(
+				"Error Creating File",
+				fmt.Sprintf("Unable to upload file in parts: %s", r.client.HandleError(err)),
+			)
+			return nil, 0, diags
+		}
+		return file, sourceSize, diags
 	}
 
-	return tmpfile.Name(), nil
+	file, err := r.client.CreateFile(ctx, client.CreateFileRequest{
+		Filename:     filename,
+		Purpose:      plan.Purpose.ValueString(),
+		Content:      io.LimitReader(reader, sourceSize),
+		ExpiresAfter: expiresAfter,
+	})
+	if err != nil {
+		diags.AddError(
+			"Error Creating File",
+			fmt.Sprintf("Unable to create file: %s", r.client.HandleError(err)),
+		)
+		return nil, 0, diags
+	}
+	return file, sourceSize, diags
 }
 
 func (r *FileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -249,10 +540,16 @@ func (r *FileResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	})
 
 	// Retrieve file information
-	file, err := r.client.OpenAI.GetFile(ctx, fileID)
+	file, err := r.client.GetFile(ctx, fileID)
 	if err != nil {
 		if apiErr, ok := err.(*openai.APIError); ok && apiErr.HTTPStatusCode == 404 {
-			// File doesn't exist anymore, remove from state
+			// File doesn't exist anymore, usually because it expired, remove from state
+			if !state.ExpiresAt.IsNull() && time.Now().Unix() >= state.ExpiresAt.ValueInt64() {
+				tflog.Info(ctx, "File expired and was removed by OpenAI", map[string]interface{}{
+					"file_id":    fileID,
+					"expires_at": state.ExpiresAt.ValueInt64(),
+				})
+			}
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -269,7 +566,9 @@ func (r *FileResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	state.Bytes = types.Int64Value(int64(file.Bytes))
 	state.CreatedAt = types.Int64Value(int64(file.CreatedAt))
 	state.Status = types.StringValue(file.Status)
-	state.StatusDetails = types.StringValue(file.StatusDetails)
+	state.StatusDetails = fileStatusDetails(file)
+	state.RemoteFilename = types.StringValue(file.FileName)
+	state.ExpiresAt = fileExpiresAt(file)
 
 	// Save into state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -291,20 +590,25 @@ func (r *FileResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	// Validate that either file_path or content is provided
-	if plan.FilePath.IsNull() && plan.Content.IsNull() {
-		resp.Diagnostics.AddError(
-			"Missing File Source",
-			"Either file_path or content must be specified",
-		)
+	// Validate that exactly one file source is provided
+	resp.Diagnostics.Append(validateFileSource(plan.FilePath, plan.Content, plan.ContentBase64)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if !plan.FilePath.IsNull() && !plan.Content.IsNull() {
-		resp.Diagnostics.AddError(
-			"Invalid Configuration",
-			"Only one of file_path or content can be specified",
-		)
+	// Settings that only affect how the file is uploaded do not need a new
+	// upload. Files uploaded before source_hash existed have no hash in state,
+	// so the hash of the same source is recorded instead of uploading again.
+	if plan.Filename.Equal(state.Filename) && plan.FilePath.Equal(state.FilePath) &&
+		plan.Content.Equal(state.Content) && plan.ContentBase64.Equal(state.ContentBase64) &&
+		plan.Purpose.Equal(state.Purpose) && (state.SourceHash.IsNull() || plan.SourceHash.Equal(state.SourceHash)) {
+		if state.SourceHash.IsNull() {
+			state.SourceHash = recordedSourceHash(plan)
+		}
+		state.MultipartPartSize = plan.MultipartPartSize
+		state.WaitUntilProcessed = plan.WaitUntilProcessed
+		state.ProcessingTimeout = plan.ProcessingTimeout
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 		return
 	}
 
@@ -324,46 +628,15 @@ func (r *FileResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		}
 	}
 
-	var fileReq openai.FileRequest
-	var tempFile string
-	var err error
-
-	// Handle file creation based on source
-	if !plan.FilePath.IsNull() {
-		fileReq = openai.FileRequest{
-			FilePath: plan.FilePath.ValueString(),
-			Purpose:  plan.Purpose.ValueString(),
-		}
-	} else {
-		// Create a temporary file with the content
-		tempFile, err = r.createTempFile(plan.Filename.ValueString(), plan.Content.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Creating Temporary File",
-				fmt.Sprintf("Unable to create temporary file: %s", err),
-			)
-			return
-		}
-		defer os.Remove(tempFile) // Clean up temporary file
-		fileReq = openai.FileRequest{
-			FilePath: tempFile,
-			FileName: plan.Filename.ValueString(), // Set the original filename
-			Purpose:  plan.Purpose.ValueString(),
-		}
-	}
-
 	tflog.Debug(ctx, "Updating file (recreate)", map[string]interface{}{
 		"filename": plan.Filename.ValueString(),
 		"purpose":  plan.Purpose.ValueString(),
 	})
 
-	// Upload the file
-	file, err := r.client.OpenAI.CreateFile(ctx, fileReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating File",
-			fmt.Sprintf("Unable to create file: %s", r.client.HandleError(err)),
-		)
+	// Upload the file, in parts when it is larger than multipart_part_size
+	file, sourceSize, uploadDiags := r.uploadFile(ctx, &plan)
+	resp.Diagnostics.Append(uploadDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -375,10 +648,22 @@ func (r *FileResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	// Keep the original filename instead of using API response
 	plan.Purpose = types.StringValue(file.Purpose)
 	plan.Status = types.StringValue(file.Status)
-	if file.StatusDetails != "" {
-		plan.StatusDetails = types.StringValue(file.StatusDetails)
-	} else {
-		plan.StatusDetails = types.StringNull()
+	plan.StatusDetails = fileStatusDetails(file)
+	plan.RemoteFilename = types.StringValue(file.FileName)
+	plan.ExpiresAt = fileExpiresAt(file)
+
+	// The API reports the size it received, which should match the source
+	if int64(file.Bytes) != sourceSize {
+		resp.Diagnostics.AddWarning(
+			"Uploaded File Size Mismatch",
+			fmt.Sprintf("File %s was uploaded with %d bytes, but the source has %d bytes.", file.ID, file.Bytes, sourceSize),
+		)
+	}
+
+	// Wait for processing so downstream resources never see an unprocessed
+	// file. The file is saved either way so a failed file is replaced.
+	if plan.WaitUntilProcessed.ValueBool() {
+		resp.Diagnostics.Append(r.waitUntilProcessed(ctx, &plan)...)
 	}
 
 	// Save into state
@@ -424,3 +709,207 @@ func (r *FileResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 func (r *FileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("object_id"), req, resp)
 }
+
+// validateFileSource checks that exactly one of file_path, content and
+// content_base64 is set and that content_base64 decodes
+func validateFileSource(filePath, content, contentBase64 types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sources := 0
+	for _, source := range []types.String{filePath, content, contentBase64} {
+		if !source.IsNull() {
+			sources++
+		}
+	}
+	if sources == 0 {
+		diags.AddError(
+			"Missing File Source",
+			"Exactly one of file_path, content or content_base64 must be specified",
+		)
+		return diags
+	}
+	if sources > 1 {
+		diags.AddError(
+			"Invalid Configuration",
+			"Only one of file_path, content or content_base64 can be specified",
+		)
+		return diags
+	}
+
+	if !contentBase64.IsNull() && !contentBase64.IsUnknown() {
+		if _, err := base64.StdEncoding.DecodeString(contentBase64.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("content_base64"),
+				"Invalid Base64 Content",
+				fmt.Sprintf("content_base64 is not valid base64: %s", err),
+			)
+		}
+	}
+
+	return diags
+}
+
+// readFileSource returns the bytes read from filePath, decoded from
+// contentBase64 or taken from content, whichever is set
+func readFileSource(filePath, content, contentBase64 types.String) ([]byte, error) {
+	switch {
+	case !filePath.IsNull():
+		return os.ReadFile(filePath.ValueString())
+	case !contentBase64.IsNull():
+		data, err := base64.StdEncoding.DecodeString(contentBase64.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("error decoding content_base64: %w", err)
+		}
+		return data, nil
+	default:
+		return []byte(content.ValueString()), nil
+	}
+}
+
+// fileSourceHash returns the hex-encoded SHA-256 checksum and size of the
+// bytes that are uploaded for the configured source
+func fileSourceHash(filePath, content, contentBase64 types.String) (string, int64, error) {
+	// Stream local files so large datasets are not held in memory
+	if !filePath.IsNull() {
+		f, err := os.Open(filePath.ValueString())
+		if err != nil {
+			return "", 0, err
+		}
+		defer f.Close()
+
+		hash := sha256.New()
+		n, err := io.Copy(hash, f)
+		if err != nil {
+			return "", 0, err
+		}
+		return hex.EncodeToString(hash.Sum(nil)), n, nil
+	}
+
+	data, err := readFileSource(filePath, content, contentBase64)
+	if err != nil {
+		return "", 0, err
+	}
+	return fsutil.SHA256Hex(data), int64(len(data)), nil
+}
+
+// recordedSourceHash returns the planned source_hash, hashing the source when
+// it could not be read at plan time. It is null when the source still cannot
+// be read, so the hash is recorded by a later apply.
+func recordedSourceHash(plan FileResourceModel) types.String {
+	if !plan.SourceHash.IsUnknown() {
+		return plan.SourceHash
+	}
+	hash, _, err := fileSourceHash(plan.FilePath, plan.Content, plan.ContentBase64)
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(hash)
+}
+
+// sourceHashModifier plans source_hash from the local file or inline content
+// and forces a new upload when the bytes can be read and differ from the
+// previous upload
+type sourceHashModifier struct{}
+
+func (m sourceHashModifier) Description(ctx context.Context) string {
+	return "Computes the checksum of the file source and requires replacement when it can be read and has changed."
+}
+
+func (m sourceHashModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m sourceHashModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var filePath, content, contentBase64 types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("file_path"), &filePath)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content"), &content)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content_base64"), &contentBase64)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The source is only known at apply time
+	if filePath.IsUnknown() || content.IsUnknown() || contentBase64.IsUnknown() ||
+		(filePath.IsNull() && content.IsNull() && contentBase64.IsNull()) {
+		m.planUncomputedHash(ctx, req, resp, filePath, content, contentBase64)
+		return
+	}
+
+	hash, _, err := fileSourceHash(filePath, content, contentBase64)
+	if err != nil {
+		// The file may be generated by another resource during apply
+		if errors.Is(err, os.ErrNotExist) {
+			m.planUncomputedHash(ctx, req, resp, filePath, content, contentBase64)
+			return
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("file_path"),
+			"Error Reading File Source",
+			fmt.Sprintf("Unable to hash %s: %s", filePath.ValueString(), err),
+		)
+		return
+	}
+
+	resp.PlanValue = types.StringValue(hash)
+	if !req.StateValue.IsNull() && req.StateValue.ValueString() != hash {
+		resp.RequiresReplace = true
+	}
+}
+
+// planUncomputedHash plans source_hash when the source cannot be hashed yet.
+// A source configured as before keeps the hash of the previous upload, so a
+// file that only exists during apply does not force a new upload on every
+// plan. A changed source leaves the hash unknown and is uploaded again by
+// Update.
+func (m sourceHashModifier) planUncomputedHash(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse, filePath, content, contentBase64 types.String) {
+	resp.PlanValue = types.StringUnknown()
+	if req.StateValue.IsNull() {
+		return
+	}
+
+	var stateFilePath, stateContent, stateContentBase64 types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("file_path"), &stateFilePath)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("content"), &stateContent)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("content_base64"), &stateContentBase64)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if filePath.Equal(stateFilePath) && content.Equal(stateContent) && contentBase64.Equal(stateContentBase64) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// fileExpiresAt returns the expiry of a file, or null when it never expires
+func fileExpiresAt(file *client.File) types.Int64 {
+	if file.ExpiresAt == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(*file.ExpiresAt)
+}
+
+// fileStatusDetails returns the status details of a file, or null when the
+// API reports none
+func fileStatusDetails(file *client.File) types.String {
+	if file.StatusDetails == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(file.StatusDetails)
+}
+
+// fileMimeType returns the MIME type the Uploads API expects for filename
+func fileMimeType(filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext == ".jsonl" {
+		return "text/jsonl"
+	}
+	if mimeType, _, err := mime.ParseMediaType(mime.TypeByExtension(ext)); err == nil {
+		return mimeType
+	}
+	return "application/octet-stream"
+}
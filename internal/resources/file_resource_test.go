@@ -0,0 +1,185 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/darnold/terraform-provider-openai/internal/fsutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testFileModel returns the state of an inline file uploaded before
+// source_hash existed
+func testFileModel(content string) FileResourceModel {
+	return FileResourceModel{
+		ID:                 types.StringValue("file-abc"),
+		Filename:           types.StringValue("notes.txt"),
+		FilePath:           types.StringNull(),
+		Content:            types.StringValue(content),
+		ContentBase64:      types.StringNull(),
+		Purpose:            types.StringValue("assistants"),
+		ObjectID:           types.StringValue("file-abc"),
+		Bytes:              types.Int64Value(int64(len(content))),
+		CreatedAt:          types.Int64Value(1700000000),
+		Status:             types.StringValue("processed"),
+		StatusDetails:      types.StringNull(),
+		SourceHash:         types.StringNull(),
+		RemoteFilename:     types.StringValue("notes.txt"),
+		MultipartPartSize:  types.Int64Null(),
+		WaitUntilProcessed: types.BoolNull(),
+		ProcessingTimeout:  types.StringNull(),
+		ExpiresAt:          types.Int64Null(),
+	}
+}
+
+// fileResourceState returns model encoded with the openai_file schema
+func fileResourceState(t *testing.T, model FileResourceModel) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&FileResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("encoding state: %v", diags)
+	}
+	return state
+}
+
+// newUnusedClient returns a client that fails the test on any request
+func newUnusedClient(t *testing.T) *client.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.NewClient(context.Background(), client.Config{APIKey: "test-key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+	return c
+}
+
+func TestSourceHashModifier(t *testing.T) {
+	hash := fsutil.SHA256Hex([]byte("hello"))
+
+	tests := []struct {
+		name             string
+		stateHash        types.String
+		wantRequiresRepl bool
+	}{
+		{
+			name:      "state from before source_hash",
+			stateHash: types.StringNull(),
+		},
+		{
+			name:      "unchanged",
+			stateHash: types.StringValue(hash),
+		},
+		{
+			name:             "changed",
+			stateHash:        types.StringValue(fsutil.SHA256Hex([]byte("goodbye"))),
+			wantRequiresRepl: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			stateModel := testFileModel("hello")
+			stateModel.SourceHash = tt.stateHash
+			state := fileResourceState(t, stateModel)
+
+			planModel := testFileModel("hello")
+			planModel.SourceHash = types.StringUnknown()
+			plan := fileResourceState(t, planModel)
+
+			req := planmodifier.StringRequest{
+				Path:        path.Root("source_hash"),
+				Config:      tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+				Plan:        tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				PlanValue:   types.StringUnknown(),
+				State:       state,
+				StateValue:  tt.stateHash,
+				ConfigValue: types.StringNull(),
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			sourceHashModifier{}.PlanModifyString(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if resp.PlanValue.ValueString() != hash {
+				t.Errorf("got planned hash %s, want %s", resp.PlanValue, hash)
+			}
+			if resp.RequiresReplace != tt.wantRequiresRepl {
+				t.Errorf("got requires replace %t, want %t", resp.RequiresReplace, tt.wantRequiresRepl)
+			}
+		})
+	}
+}
+
+func TestFileResourceUpdateRecordsMissingSourceHash(t *testing.T) {
+	hash := fsutil.SHA256Hex([]byte("hello"))
+
+	tests := []struct {
+		name        string
+		plannedHash types.String
+	}{
+		{
+			name:        "planned hash",
+			plannedHash: types.StringValue(hash),
+		},
+		{
+			name:        "hash unknown at plan time",
+			plannedHash: types.StringUnknown(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			state := fileResourceState(t, testFileModel("hello"))
+
+			planModel := testFileModel("hello")
+			planModel.SourceHash = tt.plannedHash
+			plan := fileResourceState(t, planModel)
+
+			r := &FileResource{client: newUnusedClient(t)}
+			resp := &resource.UpdateResponse{State: state}
+			r.Update(ctx, resource.UpdateRequest{
+				Plan:  tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				State: state,
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			var got FileResourceModel
+			if diags := resp.State.Get(ctx, &got); diags.HasError() {
+				t.Fatalf("decoding state: %v", diags)
+			}
+			if got.ObjectID.ValueString() != "file-abc" {
+				t.Errorf("got object_id %s, want the existing file kept", got.ObjectID)
+			}
+			if got.SourceHash.ValueString() != hash {
+				t.Errorf("got source_hash %s, want %s", got.SourceHash, hash)
+			}
+		})
+	}
+}
@@ -0,0 +1,386 @@
+package resources
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// maxFileValidationErrors caps how many problems are reported for one file
+	maxFileValidationErrors = 20
+	// minFineTuneExamples is the fewest training examples a fine-tuning job accepts
+	minFineTuneExamples = 10
+	// maxBatchRequests is the most requests a single batch input file may hold
+	maxBatchRequests = 50000
+	// planFileValidationMaxBytes bounds how much of a source is checked during
+	// plan. Larger sources are checked in full during apply.
+	planFileValidationMaxBytes = 16 * 1024 * 1024
+)
+
+// filePurposeSizeLimits is the largest file accepted for each purpose
+var filePurposeSizeLimits = map[string]int64{
+	"fine-tune":  1024 * 1024 * 1024,
+	"batch":      200 * 1024 * 1024,
+	"assistants": 512 * 1024 * 1024,
+}
+
+// assistantsFileTypes maps the extensions supported by file search and code
+// interpreter to the content type prefix sniffed from their first bytes. An
+// empty prefix means the format cannot be sniffed reliably.
+var assistantsFileTypes = map[string]string{
+	".c":     "text/",
+	".cpp":   "text/",
+	".cs":    "text/",
+	".css":   "text/",
+	".csv":   "text/",
+	".doc":   "",
+	".docx":  "application/zip",
+	".gif":   "image/gif",
+	".go":    "text/",
+	".html":  "text/",
+	".java":  "text/",
+	".jpeg":  "image/jpeg",
+	".jpg":   "image/jpeg",
+	".js":    "text/",
+	".json":  "text/",
+	".jsonl": "text/",
+	".md":    "text/",
+	".pdf":   "application/pdf",
+	".php":   "text/",
+	".pkl":   "",
+	".png":   "image/png",
+	".pptx":  "application/zip",
+	".py":    "text/",
+	".rb":    "text/",
+	".sh":    "text/",
+	".tar":   "",
+	".tex":   "text/",
+	".ts":    "text/",
+	".txt":   "text/",
+	".webp":  "image/webp",
+	".xlsx":  "application/zip",
+	".xml":   "text/",
+	".zip":   "application/zip",
+}
+
+// fineTuneRoles are the message roles accepted in fine-tuning examples
+var fineTuneRoles = map[string]bool{
+	"system":    true,
+	"developer": true,
+	"user":      true,
+	"assistant": true,
+	"tool":      true,
+	"function":  true,
+}
+
+// fileValidationErrors collects problems found in a file up to a limit
+type fileValidationErrors struct {
+	messages []string
+	total    int
+}
+
+func (e *fileValidationErrors) add(format string, args ...interface{}) {
+	e.total++
+	if len(e.messages) < maxFileValidationErrors {
+		e.messages = append(e.messages, fmt.Sprintf(format, args...))
+	}
+}
+
+func (e *fileValidationErrors) String() string {
+	msg := strings.Join(e.messages, "\n")
+	if e.total > len(e.messages) {
+		msg += fmt.Sprintf("\n... and %d more", e.total-len(e.messages))
+	}
+	return msg
+}
+
+// validateFileSourceContent checks the configured file source against the
+// rules for its purpose before it is uploaded. A file_path that does not
+// exist yet is skipped, since it may be generated during apply. When limit is
+// positive only that many bytes of the source are read.
+func validateFileSourceContent(purpose, filename string, filePath, content, contentBase64 types.String, limit int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if _, ok := filePurposeSizeLimits[purpose]; !ok {
+		return diags
+	}
+
+	var r io.Reader
+	var size int64
+	attr := path.Root("content")
+
+	switch {
+	case !filePath.IsNull():
+		attr = path.Root("file_path")
+		f, err := os.Open(filePath.ValueString())
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				diags.AddAttributeError(attr, "Error Reading File Source", fmt.Sprintf("Unable to open file: %s", err))
+			}
+			return diags
+		}
+		defer f.Close()
+
+		info, err := f.Stat()
+		if err != nil {
+			diags.AddAttributeError(attr, "Error Reading File Source", fmt.Sprintf("Unable to read file: %s", err))
+			return diags
+		}
+		r, size = f, info.Size()
+	case !contentBase64.IsNull():
+		attr = path.Root("content_base64")
+		data, err := base64.StdEncoding.DecodeString(contentBase64.ValueString())
+		if err != nil {
+			// Reported by validateFileSource
+			return diags
+		}
+		r, size = bytes.NewReader(data), int64(len(data))
+	default:
+		r, size = strings.NewReader(content.ValueString()), int64(len(content.ValueString()))
+	}
+
+	errs, err := validateFileContent(purpose, filename, r, size, limit)
+	if err != nil {
+		diags.AddAttributeError(attr, "Error Reading File Source", fmt.Sprintf("Unable to read file: %s", err))
+		return diags
+	}
+	if errs.total > 0 {
+		diags.AddAttributeError(
+			attr,
+			"Invalid File Content",
+			fmt.Sprintf("The file is not valid for purpose %q:\n%s", purpose, errs.String()),
+		)
+	}
+
+	return diags
+}
+
+// validateFileContent checks the size, format and structure of a file for
+// purpose. When limit is positive and the file is larger, only the complete
+// lines within the first limit bytes are checked, and checks that need the
+// whole file are skipped.
+func validateFileContent(purpose, filename string, r io.Reader, size int64, limit int64) (*fileValidationErrors, error) {
+	errs := &fileValidationErrors{}
+
+	if max, ok := filePurposeSizeLimits[purpose]; ok && size > max {
+		errs.add("file is %d bytes, which exceeds the %d byte limit for purpose %q", size, max, purpose)
+		return errs, nil
+	}
+
+	partial := limit > 0 && size > limit
+	if partial {
+		r = io.LimitReader(r, limit)
+	}
+
+	switch purpose {
+	case "fine-tune":
+		examples := 0
+		err := scanJSONLines(r, partial, errs, func(line int, obj map[string]json.RawMessage) {
+			examples++
+			validateFineTuneExample(line, obj, errs)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if examples < minFineTuneExamples && !partial {
+			errs.add("file has %d training examples, at least %d are required", examples, minFineTuneExamples)
+		}
+	case "batch":
+		validator := &batchValidator{customIDs: make(map[string]int)}
+		err := scanJSONLines(r, partial, errs, func(line int, obj map[string]json.RawMessage) {
+			validator.validate(line, obj, errs)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if validator.requests == 0 && !partial {
+			errs.add("file has no requests")
+		}
+		if validator.requests > maxBatchRequests {
+			errs.add("file has %d requests, at most %d are allowed", validator.requests, maxBatchRequests)
+		}
+	case "assistants":
+		ext := strings.ToLower(filepath.Ext(filename))
+		prefix, ok := assistantsFileTypes[ext]
+		if !ok {
+			errs.add("extension %q of %s is not supported for assistants", ext, filename)
+			return errs, nil
+		}
+		if prefix == "" || size == 0 {
+			return errs, nil
+		}
+
+		head := make([]byte, 512)
+		n, err := io.ReadFull(r, head)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return nil, err
+		}
+		if sniffed := http.DetectContentType(head[:n]); !strings.HasPrefix(sniffed, prefix) {
+			errs.add("content of %s looks like %s, which does not match its %s extension", filename, sniffed, ext)
+		}
+	}
+
+	return errs, nil
+}
+
+// scanJSONLines calls fn with every line of a JSONL file that holds a JSON
+// object, reporting malformed and blank lines by their 1-based number. When
+// partial is set r holds a prefix of the file, so its last line is skipped
+// unless it is complete.
+func scanJSONLines(r io.Reader, partial bool, errs *fileValidationErrors, fn func(line int, obj map[string]json.RawMessage)) error {
+	reader := bufio.NewReader(r)
+	var blank []int
+
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if len(data) == 0 && err == io.EOF {
+			break
+		}
+		if partial && err == io.EOF {
+			break
+		}
+
+		trimmed := bytes.TrimSpace(data)
+		if len(trimmed) == 0 {
+			// Blank lines are only allowed at the end of the file
+			blank = append(blank, line)
+		} else {
+			for _, b := range blank {
+				errs.add("line %d: line is empty", b)
+			}
+			blank = nil
+
+			var obj map[string]json.RawMessage
+			if jsonErr := json.Unmarshal(trimmed, &obj); jsonErr != nil {
+				errs.add("line %d: expected a JSON object: %s", line, jsonErr)
+			} else {
+				fn(line, obj)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	return nil
+}
+
+// validateFineTuneExample checks one training example in the chat,
+// preference or legacy prompt/completion format
+func validateFineTuneExample(line int, obj map[string]json.RawMessage, errs *fileValidationErrors) {
+	switch {
+	case obj["messages"] != nil:
+		if assistants := validateFineTuneMessages(line, "messages", obj["messages"], errs); assistants == 0 {
+			errs.add("line %d: messages must include at least one assistant message", line)
+		}
+	case obj["input"] != nil:
+		var input map[string]json.RawMessage
+		if err := json.Unmarshal(obj["input"], &input); err != nil || input["messages"] == nil {
+			errs.add("line %d: input must be an object with a messages array", line)
+		} else {
+			validateFineTuneMessages(line, "input.messages", input["messages"], errs)
+		}
+		for _, key := range []string{"preferred_output", "non_preferred_output"} {
+			var outputs []json.RawMessage
+			if err := json.Unmarshal(obj[key], &outputs); err != nil || len(outputs) == 0 {
+				errs.add("line %d: %s must be a non-empty array", line, key)
+			}
+		}
+	case obj["prompt"] != nil || obj["completion"] != nil:
+		var prompt, completion string
+		if json.Unmarshal(obj["prompt"], &prompt) != nil || json.Unmarshal(obj["completion"], &completion) != nil {
+			errs.add("line %d: prompt and completion must both be strings", line)
+		}
+	default:
+		errs.add("line %d: expected a messages array", line)
+	}
+}
+
+// validateFineTuneMessages checks the roles and content of a list of chat
+// messages and returns how many were written by the assistant
+func validateFineTuneMessages(line int, field string, raw json.RawMessage, errs *fileValidationErrors) int {
+	var messages []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &messages); err != nil || len(messages) == 0 {
+		errs.add("line %d: %s must be a non-empty array of objects", line, field)
+		return 0
+	}
+
+	assistants := 0
+	for i, msg := range messages {
+		var role string
+		if err := json.Unmarshal(msg["role"], &role); err != nil || role == "" {
+			errs.add("line %d: %s[%d] is missing a role", line, field, i)
+			continue
+		}
+		if !fineTuneRoles[role] {
+			errs.add("line %d: %s[%d] has unsupported role %q", line, field, i, role)
+			continue
+		}
+		if role == "assistant" {
+			assistants++
+		}
+
+		// Assistant messages may carry tool calls instead of content
+		hasContent := msg["content"] != nil && string(msg["content"]) != "null"
+		if !hasContent && (role != "assistant" || (msg["tool_calls"] == nil && msg["function_call"] == nil)) {
+			errs.add("line %d: %s[%d] (%s) is missing content", line, field, i, role)
+		}
+	}
+	return assistants
+}
+
+// batchValidator checks the lines of a batch input file
+type batchValidator struct {
+	customIDs map[string]int
+	url       string
+	requests  int
+}
+
+func (v *batchValidator) validate(line int, obj map[string]json.RawMessage, errs *fileValidationErrors) {
+	v.requests++
+
+	var customID string
+	if err := json.Unmarshal(obj["custom_id"], &customID); err != nil || customID == "" {
+		errs.add("line %d: custom_id must be a non-empty string", line)
+	} else if first, ok := v.customIDs[customID]; ok {
+		errs.add("line %d: custom_id %q is already used on line %d", line, customID, first)
+	} else {
+		v.customIDs[customID] = line
+	}
+
+	var method string
+	if err := json.Unmarshal(obj["method"], &method); err != nil || method != http.MethodPost {
+		errs.add("line %d: method must be \"POST\"", line)
+	}
+
+	var url string
+	if err := json.Unmarshal(obj["url"], &url); err != nil || !strings.HasPrefix(url, "/v1/") {
+		errs.add("line %d: url must be an API path such as \"/v1/chat/completions\"", line)
+	} else if v.url == "" {
+		v.url = url
+	} else if url != v.url {
+		errs.add("line %d: url %q differs from %q, all requests in a batch must use the same endpoint", line, url, v.url)
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(obj["body"], &body); err != nil || body == nil {
+		errs.add("line %d: body must be a JSON object", line)
+	}
+}
@@ -0,0 +1,231 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+)
+
+// fineTuneExample is a valid chat training example
+const fineTuneExample = `{"messages":[{"role":"user","content":"Hi"},{"role":"assistant","content":"Hello"}]}`
+
+// batchRequest returns a valid batch request line with the given custom_id
+func batchRequest(customID string) string {
+	return `{"custom_id":"` + customID + `","method":"POST","url":"/v1/chat/completions","body":{"model":"gpt-4o-mini"}}`
+}
+
+// jsonLines joins lines into a JSONL file with a trailing newline
+func jsonLines(lines ...string) string {
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// repeatLine returns line n times
+func repeatLine(line string, n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = line
+	}
+	return lines
+}
+
+func TestValidateFileContent(t *testing.T) {
+	examples := repeatLine(fineTuneExample, minFineTuneExamples)
+
+	tests := []struct {
+		name     string
+		purpose  string
+		filename string
+		content  string
+		size     int64
+		limit    int64
+		want     []string
+	}{
+		{
+			name:     "fine-tune",
+			purpose:  "fine-tune",
+			filename: "train.jsonl",
+			content:  jsonLines(examples...),
+		},
+		{
+			name:     "fine-tune trailing blank lines",
+			purpose:  "fine-tune",
+			filename: "train.jsonl",
+			content:  jsonLines(examples...) + "\n\n",
+		},
+		{
+			name:     "fine-tune too few examples",
+			purpose:  "fine-tune",
+			filename: "train.jsonl",
+			content:  jsonLines(fineTuneExample),
+			want:     []string{"file has 1 training examples, at least 10 are required"},
+		},
+		{
+			name:     "fine-tune malformed line",
+			purpose:  "fine-tune",
+			filename: "train.jsonl",
+			content:  jsonLines(append([]string{"not json"}, examples...)...),
+			want:     []string{"line 1: expected a JSON object"},
+		},
+		{
+			name:     "fine-tune blank line in the middle",
+			purpose:  "fine-tune",
+			filename: "train.jsonl",
+			content:  jsonLines(append([]string{fineTuneExample, ""}, examples...)...),
+			want:     []string{"line 2: line is empty"},
+		},
+		{
+			name:     "fine-tune without an assistant message",
+			purpose:  "fine-tune",
+			filename: "train.jsonl",
+			content:  jsonLines(append([]string{`{"messages":[{"role":"user","content":"Hi"}]}`}, examples...)...),
+			want:     []string{"line 1: messages must include at least one assistant message"},
+		},
+		{
+			name:     "fine-tune unsupported role",
+			purpose:  "fine-tune",
+			filename: "train.jsonl",
+			content:  jsonLines(append([]string{`{"messages":[{"role":"robot","content":"Hi"},{"role":"assistant","content":"Hello"}]}`}, examples...)...),
+			want:     []string{`line 1: messages[0] has unsupported role "robot"`},
+		},
+		{
+			name:     "fine-tune assistant tool call without content",
+			purpose:  "fine-tune",
+			filename: "train.jsonl",
+			content:  jsonLines(append([]string{`{"messages":[{"role":"user","content":"Hi"},{"role":"assistant","tool_calls":[]}]}`}, examples...)...),
+		},
+		{
+			name:     "fine-tune preference",
+			purpose:  "fine-tune",
+			filename: "train.jsonl",
+			content:  jsonLines(repeatLine(`{"input":{"messages":[{"role":"user","content":"Hi"}]},"preferred_output":[{"role":"assistant","content":"Hello"}],"non_preferred_output":[{"role":"assistant","content":"Go away"}]}`, minFineTuneExamples)...),
+		},
+		{
+			name:     "fine-tune prompt and completion",
+			purpose:  "fine-tune",
+			filename: "train.jsonl",
+			content:  jsonLines(append([]string{`{"prompt":"Hi","completion":1}`}, examples...)...),
+			want:     []string{"line 1: prompt and completion must both be strings"},
+		},
+		{
+			name:     "fine-tune partial prefix",
+			purpose:  "fine-tune",
+			filename: "train.jsonl",
+			content:  jsonLines(fineTuneExample, fineTuneExample),
+			limit:    int64(len(fineTuneExample)) + 10,
+		},
+		{
+			name:     "batch",
+			purpose:  "batch",
+			filename: "requests.jsonl",
+			content:  jsonLines(batchRequest("a"), batchRequest("b")),
+		},
+		{
+			name:     "batch duplicate custom_id",
+			purpose:  "batch",
+			filename: "requests.jsonl",
+			content:  jsonLines(batchRequest("a"), batchRequest("a")),
+			want:     []string{`line 2: custom_id "a" is already used on line 1`},
+		},
+		{
+			name:     "batch mixed endpoints",
+			purpose:  "batch",
+			filename: "requests.jsonl",
+			content:  jsonLines(batchRequest("a"), `{"custom_id":"b","method":"POST","url":"/v1/embeddings","body":{}}`),
+			want:     []string{`line 2: url "/v1/embeddings" differs from "/v1/chat/completions"`},
+		},
+		{
+			name:     "batch invalid request",
+			purpose:  "batch",
+			filename: "requests.jsonl",
+			content:  jsonLines(`{"custom_id":"","method":"GET","url":"chat","body":[]}`),
+			want: []string{
+				"line 1: custom_id must be a non-empty string",
+				`line 1: method must be "POST"`,
+				"line 1: url must be an API path",
+				"line 1: body must be a JSON object",
+			},
+		},
+		{
+			name:     "batch empty",
+			purpose:  "batch",
+			filename: "requests.jsonl",
+			content:  "",
+			want:     []string{"file has no requests"},
+		},
+		{
+			name:     "batch over the size limit",
+			purpose:  "batch",
+			filename: "requests.jsonl",
+			size:     filePurposeSizeLimits["batch"] + 1,
+			want:     []string{"file is 209715201 bytes, which exceeds the 209715200 byte limit"},
+		},
+		{
+			name:     "assistants text",
+			purpose:  "assistants",
+			filename: "notes.md",
+			content:  "# Notes\n",
+		},
+		{
+			name:     "assistants csv",
+			purpose:  "assistants",
+			filename: "data.CSV",
+			content:  "a,b\n1,2\n",
+		},
+		{
+			name:     "assistants unsupported extension",
+			purpose:  "assistants",
+			filename: "movie.mp4",
+			content:  "data",
+			want:     []string{`extension ".mp4" of movie.mp4 is not supported for assistants`},
+		},
+		{
+			name:     "assistants content does not match extension",
+			purpose:  "assistants",
+			filename: "report.pdf",
+			content:  "plain text",
+			want:     []string{"content of report.pdf looks like text/plain; charset=utf-8, which does not match its .pdf extension"},
+		},
+		{
+			name:     "other purposes are not checked",
+			purpose:  "vision",
+			filename: "image.bin",
+			content:  "anything",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size := tt.size
+			if size == 0 {
+				size = int64(len(tt.content))
+			}
+
+			errs, err := validateFileContent(tt.purpose, tt.filename, strings.NewReader(tt.content), size, tt.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(errs.messages) != len(tt.want) {
+				t.Fatalf("got problems %q, want %q", errs.messages, tt.want)
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(errs.messages[i], want) {
+					t.Errorf("got problem %q, want one starting with %q", errs.messages[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestFileValidationErrorsString(t *testing.T) {
+	errs := &fileValidationErrors{}
+	for i := 0; i < maxFileValidationErrors+3; i++ {
+		errs.add("line %d: problem", i+1)
+	}
+
+	got := errs.String()
+	if !strings.HasSuffix(got, "\n... and 3 more") {
+		t.Fatalf("got %q, want the hidden problems counted", got)
+	}
+	if strings.Count(got, "\n") != maxFileValidationErrors {
+		t.Fatalf("got %d lines, want %d", strings.Count(got, "\n")+1, maxFileValidationErrors+1)
+	}
+}
@@ -7,10 +7,13 @@ import (
 	"time"
 
 	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -21,6 +24,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &MessageResource{}
 var _ resource.ResourceWithImportState = &MessageResource{}
+var _ resource.ResourceWithValidateConfig = &MessageResource{}
 
 func NewMessageResource() resource.Resource {
 	return &MessageResource{}
@@ -38,6 +42,7 @@ type MessageResourceModel struct {
 	ThreadID    types.String      `tfsdk:"thread_id"`
 	Role        types.String      `tfsdk:"role"`
 	Content     types.String      `tfsdk:"content"`
+	ContentPart types.List        `tfsdk:"content_part"`
 	Attachments []AttachmentModel `tfsdk:"attachment"`
 	Metadata    map[string]string `tfsdk:"metadata"`
 	AssistantID types.String      `tfsdk:"assistant_id"`
@@ -45,6 +50,23 @@ type MessageResourceModel struct {
 	CreatedAt   types.Int64       `tfsdk:"created_at"`
 }
 
+// MessageContentPartModel describes a single text or image part of a message
+type MessageContentPartModel struct {
+	Type     types.String `tfsdk:"type"`
+	Text     types.String `tfsdk:"text"`
+	ImageURL types.String `tfsdk:"image_url"`
+	FileID   types.String `tfsdk:"file_id"`
+	Detail   types.String `tfsdk:"detail"`
+}
+
+var messageContentPartAttrTypes = map[string]attr.Type{
+	"type":      types.StringType,
+	"text":      types.StringType,
+	"image_url": types.StringType,
+	"file_id":   types.StringType,
+	"detail":    types.StringType,
+}
+
 // AttachmentModel describes an attachment for a message
 type AttachmentModel struct {
 	FileID types.String   `tfsdk:"file_id"`
@@ -75,21 +97,71 @@ func (r *MessageResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"thread_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the thread this message belongs to.",
+				MarkdownDescription: "The ID of the thread this message belongs to. Changing this forces a new message to be created.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"role": schema.StringAttribute{
-				MarkdownDescription: "The role of the entity that created this message. Currently supported values are 'user' or 'assistant'.",
+				MarkdownDescription: "The role of the entity that created this message. Currently supported values are 'user' or 'assistant'. Changing this forces a new message to be created.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"content": schema.StringAttribute{
-				MarkdownDescription: "The content of the message.",
-				Required:            true,
+				MarkdownDescription: "The text content of the message. Exactly one of content or content_part must be set. When content_part is used, this is the text of all text parts joined by blank lines.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
-			"attachment": schema.ListNestedAttribute{
-				MarkdownDescription: "A list of attachments for the message.",
+			"content_part": schema.ListNestedAttribute{
+				MarkdownDescription: "The content parts of the message, for messages that mix text and images. Exactly one of content or content_part must be set. When content is used, this holds the single text part.",
 				Optional:            true,
 				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the part: `text`, `image_url` or `image_file`.",
+							Required:            true,
+						},
+						"text": schema.StringAttribute{
+							MarkdownDescription: "The text of a `text` part.",
+							Optional:            true,
+						},
+						"image_url": schema.StringAttribute{
+							MarkdownDescription: "The URL of the image for an `image_url` part.",
+							Optional:            true,
+						},
+						"file_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of an uploaded image file for an `image_file` part. The file must be uploaded with purpose `vision`.",
+							Optional:            true,
+						},
+						"detail": schema.StringAttribute{
+							MarkdownDescription: "The level of detail for an image part: `auto`, `low` or `high`.",
+							Optional:            true,
+							Computed:            true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+					},
+				},
+			},
+			"attachment": schema.ListNestedAttribute{
+				MarkdownDescription: "A list of attachments for the message. Changing this forces a new message to be created.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"file_id": schema.StringAttribute{
@@ -106,7 +178,7 @@ func (r *MessageResource) Schema(ctx context.Context, req resource.SchemaRequest
 			},
 			"metadata": schema.MapAttribute{
 				ElementType:         types.StringType,
-				MarkdownDescription: "Set of key-value pairs that can be attached to an object. This can be useful for storing additional information about the object in a structured format.",
+				MarkdownDescription: "Set of key-value pairs that can be attached to an object. This can be useful for storing additional information about the object in a structured format. This is the only attribute that can be updated in place.",
 				Optional:            true,
 			},
 			"assistant_id": schema.StringAttribute{
@@ -136,6 +208,102 @@ func (r *MessageResource) Schema(ctx context.Context, req resource.SchemaRequest
 	}
 }
 
+func (r *MessageResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config MessageResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Content.IsUnknown() || config.ContentPart.IsUnknown() {
+		return
+	}
+	if config.Content.IsNull() == config.ContentPart.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content"),
+			"Invalid Message Content",
+			"Exactly one of content or content_part must be set.",
+		)
+		return
+	}
+	if config.ContentPart.IsNull() {
+		return
+	}
+
+	var parts []MessageContentPartModel
+	resp.Diagnostics.Append(config.ContentPart.ElementsAs(ctx, &parts, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, part := range parts {
+		partPath := path.Root("content_part").AtListIndex(i)
+		if part.Type.IsUnknown() {
+			continue
+		}
+
+		// Each part type takes exactly one value attribute
+		var required string
+		switch part.Type.ValueString() {
+		case "text":
+			required = "text"
+		case "image_url":
+			required = "image_url"
+		case "image_file":
+			required = "file_id"
+		default:
+			resp.Diagnostics.AddAttributeError(
+				partPath.AtName("type"),
+				"Invalid Content Part",
+				fmt.Sprintf("type must be one of text, image_url or image_file, got %q", part.Type.ValueString()),
+			)
+			continue
+		}
+
+		values := map[string]types.String{
+			"text":      part.Text,
+			"image_url": part.ImageURL,
+			"file_id":   part.FileID,
+		}
+		for name, value := range values {
+			if name == required && value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					partPath.AtName(name),
+					"Invalid Content Part",
+					fmt.Sprintf("%s must be set for a %s part", name, part.Type.ValueString()),
+				)
+			}
+			if name != required && !value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					partPath.AtName(name),
+					"Invalid Content Part",
+					fmt.Sprintf("%s cannot be set for a %s part", name, part.Type.ValueString()),
+				)
+			}
+		}
+
+		if !part.Detail.IsNull() && !part.Detail.IsUnknown() {
+			if part.Type.ValueString() == "text" {
+				resp.Diagnostics.AddAttributeError(
+					partPath.AtName("detail"),
+					"Invalid Content Part",
+					"detail can only be set for image_url and image_file parts",
+				)
+				continue
+			}
+			switch part.Detail.ValueString() {
+			case "auto", "low", "high":
+			default:
+				resp.Diagnostics.AddAttributeError(
+					partPath.AtName("detail"),
+					"Invalid Content Part",
+					fmt.Sprintf("detail must be one of auto, low or high, got %q", part.Detail.ValueString()),
+				)
+			}
+		}
+	}
+}
+
 func (r *MessageResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -161,33 +329,24 @@ func (r *MessageResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// Create the message request
-	messageReq := openai.MessageRequest{
-		Role:    plan.Role.ValueString(),
-		Content: plan.Content.ValueString(),
+	messageReq := client.CreateMessageRequest{
+		Role: plan.Role.ValueString(),
+	}
+
+	if !plan.ContentPart.IsNull() && !plan.ContentPart.IsUnknown() {
+		var parts []MessageContentPartModel
+		resp.Diagnostics.Append(plan.ContentPart.ElementsAs(ctx, &parts, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		messageReq.Content = expandContentParts(parts)
+	} else {
+		messageReq.Content = plan.Content.ValueString()
 	}
 
 	// Add optional fields if specified
 	if len(plan.Attachments) > 0 {
-		attachments := []openai.ThreadAttachment{}
-		for _, attachment := range plan.Attachments {
-			fileAttachment := openai.ThreadAttachment{
-				FileID: attachment.FileID.ValueString(),
-			}
-
-			// Convert tools from Terraform types to string slice
-			if len(attachment.Tools) > 0 {
-				tools := make([]openai.ThreadAttachmentTool, len(attachment.Tools))
-				for i, tool := range attachment.Tools {
-					tools[i] = openai.ThreadAttachmentTool{
-						Type: tool.ValueString(),
-					}
-				}
-				fileAttachment.Tools = tools
-			}
-
-			attachments = append(attachments, fileAttachment)
-		}
-		messageReq.Attachments = attachments
+		messageReq.Attachments = expandAttachments(plan.Attachments)
 	}
 
 	if len(plan.Metadata) > 0 {
@@ -200,7 +359,7 @@ func (r *MessageResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// Create the message
-	message, err := r.client.OpenAI.CreateMessage(ctx, plan.ThreadID.ValueString(), messageReq)
+	message, err := r.client.CreateMessage(ctx, plan.ThreadID.ValueString(), messageReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Message",
@@ -211,46 +370,9 @@ func (r *MessageResource) Create(ctx context.Context, req resource.CreateRequest
 
 	// Update the plan with values from the response
 	plan.ID = types.StringValue(message.ID)
-	plan.Object = types.StringValue(message.Object)
-	plan.CreatedAt = types.Int64Value(int64(message.CreatedAt))
-	plan.Role = types.StringValue(message.Role)
-
-	// Get file IDs from the message response
-	if len(message.FileIds) > 0 {
-		attachments := []AttachmentModel{}
-		for _, fileID := range message.FileIds {
-			attachments = append(attachments, AttachmentModel{
-				FileID: types.StringValue(fileID),
-				Tools:  []types.String{},
-			})
-		}
-		plan.Attachments = attachments
-	} else {
-		plan.Attachments = nil
-	}
-
-	// Ensure content is properly set from response
-	if len(message.Content) > 0 {
-		for _, content := range message.Content {
-			if content.Type == "text" {
-				plan.Content = types.StringValue(content.Text.Value)
-				break
-			}
-		}
-	}
-
-	// Handle assistant_id
-	if message.AssistantID != nil && *message.AssistantID != "" {
-		plan.AssistantID = types.StringValue(*message.AssistantID)
-	} else {
-		plan.AssistantID = types.StringNull()
-	}
-
-	// Handle run_id
-	if message.RunID != nil && *message.RunID != "" {
-		plan.RunID = types.StringValue(*message.RunID)
-	} else {
-		plan.RunID = types.StringNull()
+	resp.Diagnostics.Append(setMessageState(ctx, &plan, message)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Save the state
@@ -280,7 +402,7 @@ func (r *MessageResource) Read(ctx context.Context, req resource.ReadRequest, re
 	})
 
 	// Retrieve message information
-	message, err := r.client.OpenAI.RetrieveMessage(ctx, threadID, messageID)
+	message, err := r.client.GetMessage(ctx, threadID, messageID)
 	if err != nil {
 		if apiErr, ok := err.(*openai.APIError); ok && apiErr.HTTPStatusCode == 404 {
 			// Message doesn't exist anymore, remove from state
@@ -295,57 +417,19 @@ func (r *MessageResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	// Update state with latest values
-	state.Object = types.StringValue(message.Object)
-	state.Role = types.StringValue(message.Role)
-	state.CreatedAt = types.Int64Value(int64(message.CreatedAt))
-
-	// Set content from the first content item if available
-	if len(message.Content) > 0 && message.Content[0].Type == "text" {
-		state.Content = types.StringValue(message.Content[0].Text.Value)
-	}
-
-	// Convert file IDs to attachments
-	if len(message.FileIds) > 0 {
-		attachments := []AttachmentModel{}
-		for _, fileID := range message.FileIds {
-			attachments = append(attachments, AttachmentModel{
-				FileID: types.StringValue(fileID),
-				Tools:  []types.String{},
-			})
-		}
-		state.Attachments = attachments
-	} else {
-		state.Attachments = nil
-	}
-
-	// Handle optional fields with proper initialization
-	if message.AssistantID != nil && *message.AssistantID != "" {
-		state.AssistantID = types.StringValue(*message.AssistantID)
-	} else {
-		state.AssistantID = types.StringValue("")
+	// Content cannot be edited through the API, so a difference means the
+	// message was changed outside Terraform. The new value forces replacement.
+	if text := messageText(*message); !state.Content.IsNull() && state.Content.ValueString() != text {
+		tflog.Warn(ctx, "Message content differs from state", map[string]interface{}{
+			"thread_id":  threadID,
+			"message_id": messageID,
+		})
 	}
 
-	if message.RunID != nil && *message.RunID != "" {
-		state.RunID = types.StringValue(*message.RunID)
-	} else {
-		state.RunID = types.StringValue("")
-	}
-
-	// Convert metadata
-	if message.Metadata != nil {
-		// Convert from map[string]interface{} to map[string]string for Terraform state
-		metadataStr := make(map[string]string)
-		for k, v := range message.Metadata {
-			if strValue, ok := v.(string); ok {
-				metadataStr[k] = strValue
-			} else {
-				metadataStr[k] = fmt.Sprintf("%v", v)
-			}
-		}
-		state.Metadata = metadataStr
-	} else {
-		state.Metadata = nil
+	// Update state with latest values
+	resp.Diagnostics.Append(setMessageState(ctx, &state, message)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Save state
@@ -378,7 +462,7 @@ func (r *MessageResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// The v2 API only allows updating metadata
+	// The v2 API only allows updating metadata; every other argument forces replacement
 	if !equalMetadata(plan.Metadata, state.Metadata) {
 		// Convert metadata to the format expected by ModifyMessage
 		metadata := plan.Metadata
@@ -411,17 +495,6 @@ func (r *MessageResource) Update(ctx context.Context, req resource.UpdateRequest
 		} else {
 			plan.RunID = types.StringValue("")
 		}
-	} else {
-		// If non-metadata fields changed, return an error as they cannot be modified
-		if !plan.Content.Equal(state.Content) ||
-			!plan.Role.Equal(state.Role) ||
-			!equalAttachmentSlice(plan.Attachments, state.Attachments) {
-			resp.Diagnostics.AddError(
-				"Cannot Update Message Fields",
-				"Message content, role, and attachments cannot be modified after creation. Only metadata can be updated.",
-			)
-			return
-		}
 	}
 
 	// Save updated state
@@ -469,6 +542,132 @@ func (r *MessageResource) ImportState(ctx context.Context, req resource.ImportSt
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
 }
 
+// expandContentParts converts content_part entries into API content parts
+func expandContentParts(parts []MessageContentPartModel) []client.MessageContentPart {
+	result := make([]client.MessageContentPart, 0, len(parts))
+	for _, part := range parts {
+		contentPart := client.MessageContentPart{
+			Type: part.Type.ValueString(),
+		}
+		detail := ""
+		if !part.Detail.IsUnknown() {
+			detail = part.Detail.ValueString()
+		}
+
+		switch part.Type.ValueString() {
+		case "text":
+			contentPart.Text = part.Text.ValueString()
+		case "image_url":
+			contentPart.ImageURL = &client.MessageImageURL{
+				URL:    part.ImageURL.ValueString(),
+				Detail: detail,
+			}
+		case "image_file":
+			contentPart.ImageFile = &client.MessageImageFile{
+				FileID: part.FileID.ValueString(),
+				Detail: detail,
+			}
+		}
+		result = append(result, contentPart)
+	}
+	return result
+}
+
+// flattenContentParts converts every content part of a message into the content_part list
+func flattenContentParts(ctx context.Context, contents []client.MessageContent) (types.List, diag.Diagnostics) {
+	parts := make([]MessageContentPartModel, 0, len(contents))
+	for _, content := range contents {
+		part := MessageContentPartModel{
+			Type:     types.StringValue(content.Type),
+			Text:     types.StringNull(),
+			ImageURL: types.StringNull(),
+			FileID:   types.StringNull(),
+			Detail:   types.StringNull(),
+		}
+
+		switch {
+		case content.Text != nil:
+			part.Text = types.StringValue(content.Text.Value)
+		case content.ImageURL != nil:
+			part.ImageURL = types.StringValue(content.ImageURL.URL)
+			if content.ImageURL.Detail != "" {
+				part.Detail = types.StringValue(content.ImageURL.Detail)
+			}
+		case content.ImageFile != nil:
+			part.FileID = types.StringValue(content.ImageFile.FileID)
+			if content.ImageFile.Detail != "" {
+				part.Detail = types.StringValue(content.ImageFile.Detail)
+			}
+		}
+		parts = append(parts, part)
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: messageContentPartAttrTypes}, parts)
+}
+
+// setMessageState copies a message returned by the API into the resource model
+func setMessageState(ctx context.Context, m *MessageResourceModel, message *client.Message) diag.Diagnostics {
+	m.Object = types.StringValue(message.Object)
+	m.Role = types.StringValue(message.Role)
+	m.CreatedAt = types.Int64Value(message.CreatedAt)
+	m.Content = types.StringValue(messageText(*message))
+
+	contentParts, diags := flattenContentParts(ctx, message.Content)
+	if diags.HasError() {
+		return diags
+	}
+	m.ContentPart = contentParts
+
+	// Attachments are reported with the tools each file was added to. The
+	// existing value is kept when it only differs in representation, such as
+	// null versus empty tools, so plans only show real changes.
+	var attachments []AttachmentModel
+	for _, attachment := range message.Attachments {
+		model := AttachmentModel{
+			FileID: types.StringValue(attachment.FileID),
+		}
+		for _, tool := range attachment.Tools {
+			model.Tools = append(model.Tools, types.StringValue(tool.Type))
+		}
+		attachments = append(attachments, model)
+	}
+	if !equalAttachmentSlice(m.Attachments, attachments) {
+		m.Attachments = attachments
+	}
+
+	// Handle optional fields with proper initialization
+	if message.AssistantID != nil && *message.AssistantID != "" {
+		m.AssistantID = types.StringValue(*message.AssistantID)
+	} else {
+		m.AssistantID = types.StringValue("")
+	}
+
+	if message.RunID != nil && *message.RunID != "" {
+		m.RunID = types.StringValue(*message.RunID)
+	} else {
+		m.RunID = types.StringValue("")
+	}
+
+	// Convert from map[string]interface{} to map[string]string for Terraform
+	// state, keeping the existing value when only null and empty differ
+	var metadataStr map[string]string
+	if len(message.Metadata) > 0 {
+		metadataStr = make(map[string]string)
+		for k, v := range message.Metadata {
+			if strValue, ok := v.(string); ok {
+				metadataStr[k] = strValue
+			} else {
+				metadataStr[k] = fmt.Sprintf("%v", v)
+			}
+		}
+	}
+	if !equalMetadata(m.Metadata, metadataStr) {
+		m.Metadata = metadataStr
+	}
+
+	return diags
+}
+
 // Helper function to wait for a run to complete
 func (r *MessageResource) waitForRunCompletion(ctx context.Context, threadID, runID string) (*openai.Run, error) {
 	maxAttempts := 30
@@ -496,6 +695,30 @@ func (r *MessageResource) waitForRunCompletion(ctx context.Context, threadID, ru
 	return nil, fmt.Errorf("run did not complete within the timeout period")
 }
 
+// expandAttachments converts Terraform attachments into OpenAI message attachments
+func expandAttachments(attachments []AttachmentModel) []openai.ThreadAttachment {
+	result := []openai.ThreadAttachment{}
+	for _, attachment := range attachments {
+		fileAttachment := openai.ThreadAttachment{
+			FileID: attachment.FileID.ValueString(),
+		}
+
+		// Convert tools from Terraform types to string slice
+		if len(attachment.Tools) > 0 {
+			tools := make([]openai.ThreadAttachmentTool, len(attachment.Tools))
+			for i, tool := range attachment.Tools {
+				tools[i] = openai.ThreadAttachmentTool{
+					Type: tool.ValueString(),
+				}
+			}
+			fileAttachment.Tools = tools
+		}
+
+		result = append(result, fileAttachment)
+	}
+	return result
+}
+
 func equalMetadata(a, b map[string]string) bool {
 	if len(a) != len(b) {
 		return false
@@ -0,0 +1,169 @@
+package resources
+
+import (
+	"context"
+	"strings"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RunResponseMessageModel describes a message created by a run.
+type RunResponseMessageModel struct {
+	ID           types.String                `tfsdk:"id"`
+	Role         types.String                `tfsdk:"role"`
+	CreatedAt    types.Int64                 `tfsdk:"created_at"`
+	Text         types.String                `tfsdk:"text"`
+	ImageFileIDs []types.String              `tfsdk:"image_file_ids"`
+	Annotations  []RunMessageAnnotationModel `tfsdk:"annotations"`
+}
+
+// RunMessageAnnotationModel describes a citation or file path annotation in a message.
+type RunMessageAnnotationModel struct {
+	Type       types.String `tfsdk:"type"`
+	Text       types.String `tfsdk:"text"`
+	StartIndex types.Int64  `tfsdk:"start_index"`
+	EndIndex   types.Int64  `tfsdk:"end_index"`
+	FileID     types.String `tfsdk:"file_id"`
+}
+
+var runMessageAnnotationAttrTypes = map[string]attr.Type{
+	"type":        types.StringType,
+	"text":        types.StringType,
+	"start_index": types.Int64Type,
+	"end_index":   types.Int64Type,
+	"file_id":     types.StringType,
+}
+
+var runResponseMessageAttrTypes = map[string]attr.Type{
+	"id":         types.StringType,
+	"role":       types.StringType,
+	"created_at": types.Int64Type,
+	"text":       types.StringType,
+	"image_file_ids": types.ListType{
+		ElemType: types.StringType,
+	},
+	"annotations": types.ListType{
+		ElemType: types.ObjectType{
+			AttrTypes: runMessageAnnotationAttrTypes,
+		},
+	},
+}
+
+// runResponseMessagesSchemaAttribute returns the computed response_messages attribute shared by run resources.
+func runResponseMessagesSchemaAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "The messages created by the run, oldest first.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The ID of the message.",
+				},
+				"role": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The role of the message author.",
+				},
+				"created_at": schema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "Unix timestamp for when the message was created.",
+				},
+				"text": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The text content parts of the message, joined by blank lines.",
+				},
+				"image_file_ids": schema.ListAttribute{
+					ElementType:         types.StringType,
+					Computed:            true,
+					MarkdownDescription: "The file IDs of image_file content parts in the message.",
+				},
+				"annotations": schema.ListNestedAttribute{
+					Computed:            true,
+					MarkdownDescription: "The annotations in the message's text content parts.",
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"type": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "The type of annotation, either file_citation or file_path.",
+							},
+							"text": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "The text in the message content that is replaced by the annotation.",
+							},
+							"start_index": schema.Int64Attribute{
+								Computed:            true,
+								MarkdownDescription: "The start index of the annotation within the text.",
+							},
+							"end_index": schema.Int64Attribute{
+								Computed:            true,
+								MarkdownDescription: "The end index of the annotation within the text.",
+							},
+							"file_id": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "The ID of the file the annotation refers to.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// messageText joins the text content parts of a message with blank lines.
+func messageText(msg client.Message) string {
+	var parts []string
+	for _, content := range msg.Content {
+		if content.Type == "text" && content.Text != nil {
+			parts = append(parts, content.Text.Value)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// flattenRunMessages converts the messages created by a run into the
+// response_messages list and the concatenated response_content text.
+func flattenRunMessages(ctx context.Context, messages []client.Message) (types.List, string, diag.Diagnostics) {
+	models := make([]RunResponseMessageModel, 0, len(messages))
+	var texts []string
+
+	for _, msg := range messages {
+		model := RunResponseMessageModel{
+			ID:           types.StringValue(msg.ID),
+			Role:         types.StringValue(msg.Role),
+			CreatedAt:    types.Int64Value(msg.CreatedAt),
+			Text:         types.StringValue(messageText(msg)),
+			ImageFileIDs: []types.String{},
+			Annotations:  []RunMessageAnnotationModel{},
+		}
+
+		for _, content := range msg.Content {
+			switch {
+			case content.Type == "image_file" && content.ImageFile != nil:
+				model.ImageFileIDs = append(model.ImageFileIDs, types.StringValue(content.ImageFile.FileID))
+			case content.Type == "text" && content.Text != nil:
+				for _, annotation := range content.Text.Annotations {
+					model.Annotations = append(model.Annotations, RunMessageAnnotationModel{
+						Type:       types.StringValue(annotation.Type),
+						Text:       types.StringValue(annotation.Text),
+						StartIndex: types.Int64Value(int64(annotation.StartIndex)),
+						EndIndex:   types.Int64Value(int64(annotation.EndIndex)),
+						FileID:     types.StringValue(annotation.FileID()),
+					})
+				}
+			}
+		}
+
+		if msg.Role == "assistant" && model.Text.ValueString() != "" {
+			texts = append(texts, model.Text.ValueString())
+		}
+		models = append(models, model)
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: runResponseMessageAttrTypes}, models)
+	return list, strings.Join(texts, "\n\n"), diags
+}
@@ -0,0 +1,158 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// decodeMessages decodes messages as returned by the messages endpoint
+func decodeMessages(t *testing.T, data string) []client.Message {
+	t.Helper()
+
+	var messages []client.Message
+	if err := json.Unmarshal([]byte(data), &messages); err != nil {
+		t.Fatalf("decoding messages: %s", err)
+	}
+	return messages
+}
+
+func TestFlattenRunMessages(t *testing.T) {
+	tests := []struct {
+		name        string
+		messages    string
+		want        []RunResponseMessageModel
+		wantContent string
+	}{
+		{
+			name:     "no messages",
+			messages: `[]`,
+			want:     []RunResponseMessageModel{},
+		},
+		{
+			name: "text parts are joined",
+			messages: `[{
+				"id": "msg_1", "role": "assistant", "created_at": 100,
+				"content": [
+					{"type": "text", "text": {"value": "First", "annotations": []}},
+					{"type": "text", "text": {"value": "Second", "annotations": []}}
+				]
+			}]`,
+			want: []RunResponseMessageModel{{
+				ID:           types.StringValue("msg_1"),
+				Role:         types.StringValue("assistant"),
+				CreatedAt:    types.Int64Value(100),
+				Text:         types.StringValue("First\n\nSecond"),
+				ImageFileIDs: []types.String{},
+				Annotations:  []RunMessageAnnotationModel{},
+			}},
+			wantContent: "First\n\nSecond",
+		},
+		{
+			name: "images and annotations",
+			messages: `[{
+				"id": "msg_1", "role": "assistant", "created_at": 100,
+				"content": [
+					{"type": "image_file", "image_file": {"file_id": "file-img"}},
+					{"type": "text", "text": {"value": "See [1] and chart.csv", "annotations": [
+						{"type": "file_citation", "text": "[1]", "start_index": 4, "end_index": 7, "file_citation": {"file_id": "file-doc"}},
+						{"type": "file_path", "text": "chart.csv", "start_index": 12, "end_index": 21, "file_path": {"file_id": "file-csv"}}
+					]}}
+				]
+			}]`,
+			want: []RunResponseMessageModel{{
+				ID:           types.StringValue("msg_1"),
+				Role:         types.StringValue("assistant"),
+				CreatedAt:    types.Int64Value(100),
+				Text:         types.StringValue("See [1] and chart.csv"),
+				ImageFileIDs: []types.String{types.StringValue("file-img")},
+				Annotations: []RunMessageAnnotationModel{
+					{
+						Type:       types.StringValue("file_citation"),
+						Text:       types.StringValue("[1]"),
+						StartIndex: types.Int64Value(4),
+						EndIndex:   types.Int64Value(7),
+						FileID:     types.StringValue("file-doc"),
+					},
+					{
+						Type:       types.StringValue("file_path"),
+						Text:       types.StringValue("chart.csv"),
+						StartIndex: types.Int64Value(12),
+						EndIndex:   types.Int64Value(21),
+						FileID:     types.StringValue("file-csv"),
+					},
+				},
+			}},
+			wantContent: "See [1] and chart.csv",
+		},
+		{
+			name: "only assistant text is in the response content",
+			messages: `[
+				{"id": "msg_1", "role": "user", "created_at": 100, "content": [{"type": "text", "text": {"value": "Question", "annotations": []}}]},
+				{"id": "msg_2", "role": "assistant", "created_at": 101, "content": [{"type": "text", "text": {"value": "Answer", "annotations": []}}]},
+				{"id": "msg_3", "role": "assistant", "created_at": 102, "content": [{"type": "image_file", "image_file": {"file_id": "file-img"}}]},
+				{"id": "msg_4", "role": "assistant", "created_at": 103, "content": [{"type": "text", "text": {"value": "More", "annotations": []}}]}
+			]`,
+			want: []RunResponseMessageModel{
+				{
+					ID:           types.StringValue("msg_1"),
+					Role:         types.StringValue("user"),
+					CreatedAt:    types.Int64Value(100),
+					Text:         types.StringValue("Question"),
+					ImageFileIDs: []types.String{},
+					Annotations:  []RunMessageAnnotationModel{},
+				},
+				{
+					ID:           types.StringValue("msg_2"),
+					Role:         types.StringValue("assistant"),
+					CreatedAt:    types.Int64Value(101),
+					Text:         types.StringValue("Answer"),
+					ImageFileIDs: []types.String{},
+					Annotations:  []RunMessageAnnotationModel{},
+				},
+				{
+					ID:           types.StringValue("msg_3"),
+					Role:         types.StringValue("assistant"),
+					CreatedAt:    types.Int64Value(102),
+					Text:         types.StringValue(""),
+					ImageFileIDs: []types.String{types.StringValue("file-img")},
+					Annotations:  []RunMessageAnnotationModel{},
+				},
+				{
+					ID:           types.StringValue("msg_4"),
+					Role:         types.StringValue("assistant"),
+					CreatedAt:    types.Int64Value(103),
+					Text:         types.StringValue("More"),
+					ImageFileIDs: []types.String{},
+					Annotations:  []RunMessageAnnotationModel{},
+				},
+			},
+			wantContent: "Answer\n\nMore",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			list, content, diags := flattenRunMessages(ctx, decodeMessages(t, tt.messages))
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if content != tt.wantContent {
+				t.Errorf("got response content %q, want %q", content, tt.wantContent)
+			}
+
+			got := []RunResponseMessageModel{}
+			if diags := list.ElementsAs(ctx, &got, false); diags.HasError() {
+				t.Fatalf("decoding response messages: %v", diags)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sashabaranov/go-openai"
+)
+
+const (
+	// defaultRunPollingInterval is how often a run is polled when polling_interval is not set
+	defaultRunPollingInterval = 5 * time.Second
+	// defaultRunTimeout bounds wait_for_completion when timeout is not set
+	defaultRunTimeout = 10 * time.Minute
+)
+
+// RunAdditionalMessageModel describes a message added to the thread when the run is created.
+type RunAdditionalMessageModel struct {
+	Role        types.String      `tfsdk:"role"`
+	Content     types.String      `tfsdk:"content"`
+	Attachments []AttachmentModel `tfsdk:"attachment"`
+	Metadata    map[string]string `tfsdk:"metadata"`
+}
+
+// RunTruncationStrategyModel describes how the thread is truncated before the run.
+type RunTruncationStrategyModel struct {
+	Type         types.String `tfsdk:"type"`
+	LastMessages types.Int64  `tfsdk:"last_messages"`
+}
+
+// runMessagesSchemaBlock returns the block of messages a run adds to its
+// thread, shared by openai_run and openai_thread_run
+func runMessagesSchemaBlock(description string) schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		MarkdownDescription: description,
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.RequiresReplace(),
+		},
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"role": schema.StringAttribute{
+					MarkdownDescription: "The role of the entity creating the message. Must be either \"user\" or \"assistant\".",
+					Required:            true,
+				},
+				"content": schema.StringAttribute{
+					MarkdownDescription: "The content of the message.",
+					Required:            true,
+				},
+				"attachment": schema.ListNestedAttribute{
+					MarkdownDescription: "A list of files attached to the message and the tools they should be added to.",
+					Optional:            true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"file_id": schema.StringAttribute{
+								MarkdownDescription: "The ID of the file to attach.",
+								Required:            true,
+							},
+							"tools": schema.ListAttribute{
+								ElementType:         types.StringType,
+								MarkdownDescription: "A list of tools associated with the attachment.",
+								Optional:            true,
+							},
+						},
+					},
+				},
+				"metadata": schema.MapAttribute{
+					ElementType:         types.StringType,
+					Optional:            true,
+					MarkdownDescription: "A map of key-value pairs that can be used to store additional information about the message.",
+				},
+			},
+		},
+	}
+}
+
+// runTruncationStrategySchemaBlock returns the truncation_strategy block
+// shared by openai_run and openai_thread_run
+func runTruncationStrategySchemaBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Controls how the thread is truncated to fit the context window before the run.",
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplaceIf(
+				truncationStrategyChanged,
+				"Changing the truncation strategy forces a new run. Leaving the block out is the same as the auto strategy.",
+				"Changing the truncation strategy forces a new run. Leaving the block out is the same as the `auto` strategy.",
+			),
+		},
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The truncation strategy, either `auto` or `last_messages`.",
+				Required:            true,
+			},
+			"last_messages": schema.Int64Attribute{
+				MarkdownDescription: "The number of most recent messages to keep when type is `last_messages`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// truncationStrategyChanged forces a new run when the truncation strategy
+// changes. An absent block and the auto strategy are the same, which is how
+// an imported run records the default strategy.
+func truncationStrategyChanged(_ context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !isAutoTruncationStrategy(req.PlanValue) || !isAutoTruncationStrategy(req.StateValue)
+}
+
+// isAutoTruncationStrategy reports whether a truncation_strategy block is
+// absent or uses the auto strategy
+func isAutoTruncationStrategy(value types.Object) bool {
+	if value.IsNull() {
+		return true
+	}
+	strategyType, ok := value.Attributes()["type"].(types.String)
+	return ok && strategyType.ValueString() == string(openai.TruncationStrategyAuto)
+}
+
+// validateRunOptions checks the sampling, response format and truncation
+// options shared by openai_run and openai_thread_run
+func validateRunOptions(temperature, topP types.Float64, responseFormat types.String, truncationStrategy *RunTruncationStrategyModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !temperature.IsNull() && !temperature.IsUnknown() {
+		if t := temperature.ValueFloat64(); t < 0 || t > 2 {
+			diags.AddAttributeError(
+				path.Root("temperature"),
+				"Invalid Temperature",
+				fmt.Sprintf("temperature must be between 0 and 2, got %g", t),
+			)
+		}
+	}
+
+	if !topP.IsNull() && !topP.IsUnknown() {
+		if p := topP.ValueFloat64(); p < 0 || p > 1 {
+			diags.AddAttributeError(
+				path.Root("top_p"),
+				"Invalid Top P",
+				fmt.Sprintf("top_p must be between 0 and 1, got %g", p),
+			)
+		}
+	}
+
+	if !responseFormat.IsNull() && !responseFormat.IsUnknown() {
+		switch responseFormat.ValueString() {
+		case "auto", "text", "json_object":
+		default:
+			diags.AddAttributeError(
+				path.Root("response_format"),
+				"Invalid Response Format",
+				fmt.Sprintf("response_format must be one of auto, text or json_object, got %q", responseFormat.ValueString()),
+			)
+		}
+	}
+
+	// A block without type is reported as a missing required attribute
+	if ts := truncationStrategy; ts != nil && !ts.Type.IsNull() && !ts.Type.IsUnknown() && !ts.LastMessages.IsUnknown() {
+		switch ts.Type.ValueString() {
+		case "auto":
+			if !ts.LastMessages.IsNull() {
+				diags.AddAttributeError(
+					path.Root("truncation_strategy").AtName("last_messages"),
+					"Invalid Truncation Strategy",
+					"last_messages can only be set when type is last_messages",
+				)
+			}
+		case "last_messages":
+			if ts.LastMessages.IsNull() || ts.LastMessages.ValueInt64() < 1 {
+				diags.AddAttributeError(
+					path.Root("truncation_strategy").AtName("last_messages"),
+					"Invalid Truncation Strategy",
+					"last_messages must be set to at least 1 when type is last_messages",
+				)
+			}
+		default:
+			diags.AddAttributeError(
+				path.Root("truncation_strategy").AtName("type"),
+				"Invalid Truncation Strategy",
+				fmt.Sprintf("type must be either auto or last_messages, got %q", ts.Type.ValueString()),
+			)
+		}
+	}
+
+	return diags
+}
+
+// expandRunMessages converts message blocks into messages for a run request
+func expandRunMessages(messages []RunAdditionalMessageModel) []openai.ThreadMessage {
+	var threadMessages []openai.ThreadMessage
+	for _, msg := range messages {
+		threadMsg := openai.ThreadMessage{
+			Role:    openai.ThreadMessageRole(msg.Role.ValueString()),
+			Content: msg.Content.ValueString(),
+		}
+		if len(msg.Attachments) > 0 {
+			threadMsg.Attachments = expandAttachments(msg.Attachments)
+		}
+		if len(msg.Metadata) > 0 {
+			threadMsg.Metadata = make(map[string]any)
+			for k, v := range msg.Metadata {
+				threadMsg.Metadata[k] = v
+			}
+		}
+		threadMessages = append(threadMessages, threadMsg)
+	}
+	return threadMessages
+}
+
+// expandTruncationStrategy converts a truncation_strategy block into its API form
+func expandTruncationStrategy(ts *RunTruncationStrategyModel) *openai.ThreadTruncationStrategy {
+	if ts == nil || ts.Type.IsNull() {
+		return nil
+	}
+	strategy := &openai.ThreadTruncationStrategy{
+		Type: openai.TruncationStrategy(ts.Type.ValueString()),
+	}
+	if !ts.LastMessages.IsNull() {
+		lastMessages := int(ts.LastMessages.ValueInt64())
+		strategy.LastMessages = &lastMessages
+	}
+	return strategy
+}
+
+// flattenTruncationStrategy converts the truncation strategy of a run into a
+// truncation_strategy block, which is left out for the default strategy
+func flattenTruncationStrategy(strategy *openai.ThreadTruncationStrategy) *RunTruncationStrategyModel {
+	if strategy == nil || strategy.Type == "" || strategy.Type == openai.TruncationStrategyAuto {
+		return nil
+	}
+	ts := &RunTruncationStrategyModel{
+		Type:         types.StringValue(string(strategy.Type)),
+		LastMessages: types.Int64Null(),
+	}
+	if strategy.LastMessages != nil {
+		ts.LastMessages = types.Int64Value(int64(*strategy.LastMessages))
+	}
+	return ts
+}
+
+// parseRunWaitSettings reads wait_for_completion, polling_interval and timeout,
+// applying their defaults
+func parseRunWaitSettings(waitForCompletion types.Bool, pollingInterval, timeout types.String) (bool, time.Duration, time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	wait := true
+	if !waitForCompletion.IsNull() {
+		wait = waitForCompletion.ValueBool()
+	}
+
+	interval := defaultRunPollingInterval
+	if !pollingInterval.IsNull() {
+		d, err := time.ParseDuration(pollingInterval.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Invalid Polling Interval",
+				fmt.Sprintf("Unable to parse polling interval: %s", err),
+			)
+			return false, 0, 0, diags
+		}
+		interval = d
+	}
+
+	limit := defaultRunTimeout
+	if !timeout.IsNull() {
+		d, err := time.ParseDuration(timeout.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Invalid Timeout",
+				fmt.Sprintf("Unable to parse timeout: %s", err),
+			)
+			return false, 0, 0, diags
+		}
+		limit = d
+	}
+
+	return wait, interval, limit, diags
+}
@@ -0,0 +1,123 @@
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/darnold/terraform-provider-openai/internal/fsutil"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RunOutputFileModel describes a file produced by a run and downloaded locally.
+type RunOutputFileModel struct {
+	FileID   types.String `tfsdk:"file_id"`
+	Filename types.String `tfsdk:"filename"`
+	Path     types.String `tfsdk:"path"`
+	Bytes    types.Int64  `tfsdk:"bytes"`
+	SHA256   types.String `tfsdk:"sha256"`
+}
+
+var runOutputFileAttrTypes = map[string]attr.Type{
+	"file_id":  types.StringType,
+	"filename": types.StringType,
+	"path":     types.StringType,
+	"bytes":    types.Int64Type,
+	"sha256":   types.StringType,
+}
+
+// runOutputFile is a file referenced by a run's messages
+type runOutputFile struct {
+	fileID  string
+	isImage bool
+}
+
+// collectRunOutputFiles returns the IDs of files produced by a run, taken from
+// image_file content parts and file_path annotations, in message order and
+// without duplicates. File citations point at input files and are skipped.
+func collectRunOutputFiles(messages []client.Message) []runOutputFile {
+	var files []runOutputFile
+	seen := make(map[string]bool)
+
+	add := func(fileID string, isImage bool) {
+		if fileID == "" || seen[fileID] {
+			return
+		}
+		seen[fileID] = true
+		files = append(files, runOutputFile{fileID: fileID, isImage: isImage})
+	}
+
+	for _, msg := range messages {
+		for _, content := range msg.Content {
+			switch {
+			case content.Type == "image_file" && content.ImageFile != nil:
+				add(content.ImageFile.FileID, true)
+			case content.Type == "text" && content.Text != nil:
+				for _, annotation := range content.Text.Annotations {
+					if annotation.FilePath != nil {
+						add(annotation.FilePath.FileID, false)
+					}
+				}
+			}
+		}
+	}
+
+	return files
+}
+
+// downloadRunOutputFiles downloads the files produced by a run into dir. Files
+// are named after their ID and original name so repeated runs never collide,
+// and each file is written to a temporary name first so a failed download does
+// not leave a truncated file behind.
+func downloadRunOutputFiles(ctx context.Context, c *client.Client, dir string, messages []client.Message) ([]RunOutputFileModel, error) {
+	outputs := []RunOutputFileModel{}
+
+	files := collectRunOutputFiles(messages)
+	if len(files) == 0 {
+		return outputs, nil
+	}
+
+	for _, f := range files {
+		// Code interpreter files are named after their sandbox path, e.g. /mnt/data/chart.png
+		filename := ""
+		if file, err := c.OpenAI.GetFile(ctx, f.fileID); err == nil && file.FileName != "" {
+			filename = path.Base(file.FileName)
+		}
+
+		localName := f.fileID
+		switch {
+		case filename != "":
+			localName = f.fileID + "-" + filename
+		case f.isImage:
+			localName = f.fileID + ".png"
+		}
+		dest := filepath.Join(dir, localName)
+
+		hash := sha256.New()
+		var n int64
+		err := fsutil.WriteAtomic(dest, func(w io.Writer) error {
+			var err error
+			n, err = c.DownloadFile(ctx, f.fileID, io.MultiWriter(w, hash))
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error writing file %s: %w", f.fileID, err)
+		}
+
+		outputs = append(outputs, RunOutputFileModel{
+			FileID:   types.StringValue(f.fileID),
+			Filename: types.StringValue(filename),
+			Path:     types.StringValue(dest),
+			Bytes:    types.Int64Value(n),
+			SHA256:   types.StringValue(hex.EncodeToString(hash.Sum(nil))),
+		})
+	}
+
+	return outputs, nil
+}
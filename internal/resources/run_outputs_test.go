@@ -0,0 +1,86 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCollectRunOutputFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages string
+		want     []runOutputFile
+	}{
+		{
+			name:     "no messages",
+			messages: `[]`,
+		},
+		{
+			name: "text without annotations",
+			messages: `[{"id": "msg_1", "role": "assistant", "content": [
+				{"type": "text", "text": {"value": "Done", "annotations": []}}
+			]}]`,
+		},
+		{
+			name: "images and file paths in message order",
+			messages: `[
+				{"id": "msg_1", "role": "assistant", "content": [
+					{"type": "image_file", "image_file": {"file_id": "file-img"}},
+					{"type": "text", "text": {"value": "report.csv", "annotations": [
+						{"type": "file_path", "text": "report.csv", "file_path": {"file_id": "file-csv"}}
+					]}}
+				]},
+				{"id": "msg_2", "role": "assistant", "content": [
+					{"type": "text", "text": {"value": "data.json", "annotations": [
+						{"type": "file_path", "text": "data.json", "file_path": {"file_id": "file-json"}}
+					]}}
+				]}
+			]`,
+			want: []runOutputFile{
+				{fileID: "file-img", isImage: true},
+				{fileID: "file-csv"},
+				{fileID: "file-json"},
+			},
+		},
+		{
+			name: "citations are skipped",
+			messages: `[{"id": "msg_1", "role": "assistant", "content": [
+				{"type": "text", "text": {"value": "[1]", "annotations": [
+					{"type": "file_citation", "text": "[1]", "file_citation": {"file_id": "file-doc"}}
+				]}}
+			]}]`,
+		},
+		{
+			name: "duplicates are kept once",
+			messages: `[
+				{"id": "msg_1", "role": "assistant", "content": [
+					{"type": "image_file", "image_file": {"file_id": "file-img"}},
+					{"type": "text", "text": {"value": "chart.png", "annotations": [
+						{"type": "file_path", "text": "chart.png", "file_path": {"file_id": "file-img"}}
+					]}}
+				]},
+				{"id": "msg_2", "role": "assistant", "content": [
+					{"type": "image_file", "image_file": {"file_id": "file-img"}}
+				]}
+			]`,
+			want: []runOutputFile{
+				{fileID: "file-img", isImage: true},
+			},
+		},
+		{
+			name: "external images are skipped",
+			messages: `[{"id": "msg_1", "role": "user", "content": [
+				{"type": "image_url", "image_url": {"url": "https://example.com/cat.png"}}
+			]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collectRunOutputFiles(decodeMessages(t, tt.messages))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,16 +2,21 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/darnold/terraform-provider-openai/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -23,6 +28,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &RunResource{}
 var _ resource.ResourceWithImportState = &RunResource{}
+var _ resource.ResourceWithValidateConfig = &RunResource{}
 
 func NewRunResource() resource.Resource {
 	return &RunResource{}
@@ -35,30 +41,41 @@ type RunResource struct {
 
 // RunResourceModel describes the resource data model.
 type RunResourceModel struct {
-	ID                  types.String `tfsdk:"id"`
-	ThreadID            types.String `tfsdk:"thread_id"`
-	AssistantID         types.String `tfsdk:"assistant_id"`
-	Status              types.String `tfsdk:"status"`
-	Model               types.String `tfsdk:"model"`
-	Instructions        types.String `tfsdk:"instructions"`
-	Tools               types.List   `tfsdk:"tools"`
-	WaitForCompletion   types.Bool   `tfsdk:"wait_for_completion"`
-	PollingInterval     types.String `tfsdk:"polling_interval"`
-	Timeout             types.String `tfsdk:"timeout"`
-	CreatedAt           types.Int64  `tfsdk:"created_at"`
-	ExpiresAt           types.Int64  `tfsdk:"expires_at"`
-	StartedAt           types.Int64  `tfsdk:"started_at"`
-	CancelledAt         types.Int64  `tfsdk:"cancelled_at"`
-	FailedAt            types.Int64  `tfsdk:"failed_at"`
-	CompletedAt         types.Int64  `tfsdk:"completed_at"`
-	LastError           types.String `tfsdk:"last_error"`
-	Steps               types.List   `tfsdk:"steps"`
-	RequiredAction      types.Object `tfsdk:"required_action"`
-	Metadata            types.Map    `tfsdk:"metadata"`
-	MaxPromptTokens     types.Int64  `tfsdk:"max_prompt_tokens"`
-	MaxCompletionTokens types.Int64  `tfsdk:"max_completion_tokens"`
-	ResponseContent     types.String `tfsdk:"response_content"`
-	IncompleteDetails   types.String `tfsdk:"incomplete_details"`
+	ID                     types.String                `tfsdk:"id"`
+	ThreadID               types.String                `tfsdk:"thread_id"`
+	AssistantID            types.String                `tfsdk:"assistant_id"`
+	Status                 types.String                `tfsdk:"status"`
+	Model                  types.String                `tfsdk:"model"`
+	Instructions           types.String                `tfsdk:"instructions"`
+	Tools                  types.List                  `tfsdk:"tools"`
+	AdditionalInstructions types.String                `tfsdk:"additional_instructions"`
+	AdditionalMessages     []RunAdditionalMessageModel `tfsdk:"additional_messages"`
+	TruncationStrategy     *RunTruncationStrategyModel `tfsdk:"truncation_strategy"`
+	Temperature            types.Float64               `tfsdk:"temperature"`
+	TopP                   types.Float64               `tfsdk:"top_p"`
+	ResponseFormat         types.String                `tfsdk:"response_format"`
+	WaitForCompletion      types.Bool                  `tfsdk:"wait_for_completion"`
+	Stream                 types.Bool                  `tfsdk:"stream"`
+	PollingInterval        types.String                `tfsdk:"polling_interval"`
+	Timeout                types.String                `tfsdk:"timeout"`
+	CreatedAt              types.Int64                 `tfsdk:"created_at"`
+	ExpiresAt              types.Int64                 `tfsdk:"expires_at"`
+	StartedAt              types.Int64                 `tfsdk:"started_at"`
+	CancelledAt            types.Int64                 `tfsdk:"cancelled_at"`
+	FailedAt               types.Int64                 `tfsdk:"failed_at"`
+	CompletedAt            types.Int64                 `tfsdk:"completed_at"`
+	LastError              types.String                `tfsdk:"last_error"`
+	Steps                  types.List                  `tfsdk:"steps"`
+	Usage                  types.Object                `tfsdk:"usage"`
+	RequiredAction         types.Object                `tfsdk:"required_action"`
+	Metadata               types.Map                   `tfsdk:"metadata"`
+	MaxPromptTokens        types.Int64                 `tfsdk:"max_prompt_tokens"`
+	MaxCompletionTokens    types.Int64                 `tfsdk:"max_completion_tokens"`
+	ResponseContent        types.String                `tfsdk:"response_content"`
+	ResponseMessages       types.List                  `tfsdk:"response_messages"`
+	OutputDirectory        types.String                `tfsdk:"output_directory"`
+	OutputFiles            types.List                  `tfsdk:"output_files"`
+	IncompleteDetails      types.String                `tfsdk:"incomplete_details"`
 }
 
 func (r *RunResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -80,10 +97,16 @@ func (r *RunResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 			"thread_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The ID of the thread to run the assistant on.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"assistant_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The ID of the assistant to use for this run.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
@@ -91,21 +114,74 @@ func (r *RunResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 			},
 			"model": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Override the default model used by the assistant.",
+				Computed:            true,
+				MarkdownDescription: "Override the default model used by the assistant. Defaults to the model the run was created with.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
 			"instructions": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Override the default instructions of the assistant for this run.",
+				Computed:            true,
+				MarkdownDescription: "Override the default instructions of the assistant for this run. Defaults to the instructions the run was created with.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
 			"tools": schema.ListAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
-				MarkdownDescription: "Override the default tools of the assistant for this run.",
+				Computed:            true,
+				MarkdownDescription: "Override the default tools of the assistant for this run. Defaults to the types of the tools the run was created with.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+					listplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"additional_instructions": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Instructions appended to the assistant's instructions for this run only.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"temperature": schema.Float64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Sampling temperature between 0 and 2 for this run. Higher values make the output more random. Defaults to the temperature the run was created with.",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+					float64planmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"top_p": schema.Float64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Nucleus sampling probability mass between 0 and 1 for this run. An alternative to temperature. Defaults to the value the run was created with.",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+					float64planmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"response_format": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The format the model must output for this run. One of `auto`, `text` or `json_object`. Defaults to the format the run was created with.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
 			"wait_for_completion": schema.BoolAttribute{
 				Optional:            true,
 				MarkdownDescription: "Whether to wait for the run to complete before marking the resource as created. Defaults to true.",
 			},
+			"stream": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create the run with server-sent events and finish as soon as the run reaches a terminal status instead of polling. Only applies when wait_for_completion is true. Defaults to false.",
+			},
 			"polling_interval": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "How often to poll for run status when wait_for_completion is true. Defaults to 5s.",
@@ -154,10 +230,11 @@ func (r *RunResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"steps": schema.ListAttribute{
-				ElementType:         types.StringType,
+			"steps": runStepsSchemaAttribute(),
+			"usage": schema.SingleNestedAttribute{
 				Computed:            true,
-				MarkdownDescription: "The steps taken during the run.",
+				MarkdownDescription: "Token usage for the run. Only set once the run has reached a terminal status.",
+				Attributes:          runUsageSchemaAttributes(),
 			},
 			"required_action": schema.ObjectAttribute{
 				AttributeTypes: map[string]attr.Type{
@@ -190,7 +267,12 @@ func (r *RunResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 			"metadata": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
-				MarkdownDescription: "Set of key-value pairs for run metadata.",
+				Computed:            true,
+				MarkdownDescription: "Set of key-value pairs for run metadata. Defaults to the metadata the run was created with.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+					mapplanmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
 			"max_prompt_tokens": schema.Int64Attribute{
 				MarkdownDescription: "The maximum number of tokens to use for prompts in this run. When using File Search tool, recommend setting to at least 50000 for best results.",
@@ -198,6 +280,7 @@ func (r *RunResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Computed:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"max_completion_tokens": schema.Int64Attribute{
@@ -206,11 +289,51 @@ func (r *RunResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Computed:            true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
 				},
 			},
 			"response_content": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "The content of the assistant's response after the run completes.",
+				MarkdownDescription: "The text of every assistant message created by the run, joined by blank lines.",
+			},
+			"response_messages": runResponseMessagesSchemaAttribute(),
+			"output_directory": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Local directory to download files produced by the run into, such as code interpreter charts (`image_file` content) and generated files (`file_path` annotations). Files are named `<file_id>-<original name>`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"output_files": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Files produced by the run and downloaded into output_directory.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"file_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the file.",
+						},
+						"filename": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The original name of the file, if known.",
+						},
+						"path": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The local path the file was written to.",
+						},
+						"bytes": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The size of the file in bytes.",
+						},
+						"sha256": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The hex-encoded SHA-256 checksum of the file content.",
+						},
+					},
+				},
 			},
 			"incomplete_details": schema.StringAttribute{
 				Computed:            true,
@@ -220,7 +343,22 @@ func (r *RunResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				},
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"additional_messages": runMessagesSchemaBlock("Messages added to the thread before the run starts."),
+			"truncation_strategy": runTruncationStrategySchemaBlock(),
+		},
+	}
+}
+
+func (r *RunResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RunResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	resp.Diagnostics.Append(validateRunOptions(data.Temperature, data.TopP, data.ResponseFormat, data.TruncationStrategy)...)
 }
 
 func (r *RunResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -249,7 +387,7 @@ func (r *RunResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	// Convert tools to OpenAI format if provided
 	var tools []openai.AssistantTool
-	if !data.Tools.IsNull() {
+	if !data.Tools.IsNull() && !data.Tools.IsUnknown() {
 		var toolNames []string
 		resp.Diagnostics.Append(data.Tools.ElementsAs(ctx, &toolNames, false)...)
 		if resp.Diagnostics.HasError() {
@@ -266,11 +404,11 @@ func (r *RunResource) Create(ctx context.Context, req resource.CreateRequest, re
 		ThreadID:    data.ThreadID.ValueString(),
 	}
 
-	if !data.Model.IsNull() {
+	if !data.Model.IsNull() && !data.Model.IsUnknown() {
 		createReq.Model = data.Model.ValueString()
 	}
 
-	if !data.Instructions.IsNull() {
+	if !data.Instructions.IsNull() && !data.Instructions.IsUnknown() {
 		createReq.Instructions = data.Instructions.ValueString()
 	}
 
@@ -278,7 +416,7 @@ func (r *RunResource) Create(ctx context.Context, req resource.CreateRequest, re
 		createReq.Tools = tools
 	}
 
-	if !data.Metadata.IsNull() {
+	if !data.Metadata.IsNull() && !data.Metadata.IsUnknown() {
 		metadata := make(map[string]any)
 		resp.Diagnostics.Append(data.Metadata.ElementsAs(ctx, &metadata, false)...)
 		if resp.Diagnostics.HasError() {
@@ -295,116 +433,230 @@ func (r *RunResource) Create(ctx context.Context, req resource.CreateRequest, re
 		createReq.MaxCompletionTokens = int(data.MaxCompletionTokens.ValueInt64())
 	}
 
-	// Create run
-	run, err := r.client.CreateRun(ctx, createReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Run",
-			fmt.Sprintf("Unable to create run: %s", err),
-		)
+	if !data.AdditionalInstructions.IsNull() {
+		createReq.AdditionalInstructions = data.AdditionalInstructions.ValueString()
+	}
+
+	createReq.AdditionalMessages = expandRunMessages(data.AdditionalMessages)
+	createReq.TruncationStrategy = expandTruncationStrategy(data.TruncationStrategy)
+
+	if !data.Temperature.IsNull() && !data.Temperature.IsUnknown() {
+		temperature := float32(data.Temperature.ValueFloat64())
+		createReq.Temperature = &temperature
+	}
+
+	if !data.TopP.IsNull() && !data.TopP.IsUnknown() {
+		topP := float32(data.TopP.ValueFloat64())
+		createReq.TopP = &topP
+	}
+
+	if !data.ResponseFormat.IsNull() && !data.ResponseFormat.IsUnknown() {
+		createReq.ResponseFormat = data.ResponseFormat.ValueString()
+	}
+
+	// Parse the wait settings before creating the run so invalid values fail early
+	waitForCompletion, pollingInterval, timeout, diags := parseRunWaitSettings(data.WaitForCompletion, data.PollingInterval, data.Timeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Store the thread ID and run ID for later use
+	stream := false
+	if !data.Stream.IsNull() {
+		stream = data.Stream.ValueBool()
+	}
+
+	// Store the thread ID for later use
 	threadID := data.ThreadID.ValueString()
 
-	// Update any associated messages with the run ID and assistant ID
+	// Remember the most recent message so it can be tagged with the run ID.
+	// This is looked up before the run starts so a streamed run cannot have
+	// added its own reply in the meantime.
+	latestMessageID := ""
 	messages, err := r.client.OpenAI.ListMessage(ctx, threadID, nil, nil, nil, nil, nil)
 	if err == nil && len(messages.Messages) > 0 {
-		// Get the most recent message
-		latestMsg := messages.Messages[0]
+		latestMessageID = messages.Messages[0].ID
+	}
+
+	// Create run
+	var run *openai.Run
+	startTime := time.Now()
+	if waitForCompletion && stream {
+		streamCtx, cancel := context.WithTimeout(ctx, timeout)
+		result, err := r.client.CreateRunStream(streamCtx, createReq)
+		cancel()
+		if err != nil {
+			if result == nil || result.RunID == "" {
+				if errors.Is(streamCtx.Err(), context.DeadlineExceeded) {
+					resp.Diagnostics.AddError(
+						"Run Timeout",
+						fmt.Sprintf("Run did not complete within %s", timeout),
+					)
+					return
+				}
+				resp.Diagnostics.AddError(
+					"Error Creating Run",
+					fmt.Sprintf("Unable to create run: %s", err),
+				)
+				return
+			}
+
+			// The run exists even if the stream broke before its state was
+			// decoded, so it is either cancelled or polled for the rest
+			if result.Run == nil {
+				result.Run = &openai.Run{ID: result.RunID, ThreadID: threadID, Status: openai.RunStatusQueued}
+			}
+			if ctx.Err() != nil || errors.Is(streamCtx.Err(), context.DeadlineExceeded) {
+				r.abortRun(ctx, &data, result.Run, timeout, resp)
+				return
+			}
+			tflog.Warn(ctx, fmt.Sprintf("Run stream interrupted, falling back to polling: %v", err))
+		}
+		tflog.Debug(ctx, "Run stream finished", map[string]interface{}{
+			"run_id": result.Run.ID,
+			"status": string(result.Run.Status),
+			"text":   result.Text(),
+		})
+		run = result.Run
+	} else {
+		run, err = r.client.CreateRun(ctx, createReq)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Creating Run",
+				fmt.Sprintf("Unable to create run: %s", err),
+			)
+			return
+		}
+	}
+
+	// Update the latest message with the run ID and assistant ID
+	if latestMessageID != "" {
 		metadata := make(map[string]string)
 		metadata["run_id"] = run.ID
 		metadata["assistant_id"] = data.AssistantID.ValueString()
 
-		_, err = r.client.OpenAI.ModifyMessage(ctx, threadID, latestMsg.ID, metadata)
+		_, err = r.client.OpenAI.ModifyMessage(ctx, threadID, latestMessageID, metadata)
 		if err != nil {
 			tflog.Warn(ctx, fmt.Sprintf("Failed to update message with run ID: %v", err))
 		}
 	}
 
-	// Wait for completion if requested
-	waitForCompletion := true
-	if !data.WaitForCompletion.IsNull() {
-		waitForCompletion = data.WaitForCompletion.ValueBool()
-	}
-
+	// Wait for completion if requested. A streamed run normally arrives here in
+	// a terminal status, in which case no polling requests are made.
 	if waitForCompletion {
-		pollingInterval := 5 * time.Second
-		if !data.PollingInterval.IsNull() {
-			d, err := time.ParseDuration(data.PollingInterval.ValueString())
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Invalid Polling Interval",
-					fmt.Sprintf("Unable to parse polling interval: %s", err),
-				)
-				return
-			}
-			pollingInterval = d
+		var aborted bool
+		run, aborted, diags = waitForRun(ctx, r.client, run, pollingInterval, timeout, startTime)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if aborted {
+			r.abortRun(ctx, &data, run, timeout, resp)
+			return
 		}
+	}
 
-		timeout := 10 * time.Minute
-		if !data.Timeout.IsNull() {
-			d, err := time.ParseDuration(data.Timeout.ValueString())
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Invalid Timeout",
-					fmt.Sprintf("Unable to parse timeout: %s", err),
-				)
-				return
-			}
-			timeout = d
+	// Update Terraform state
+	runMessages, diags := r.setRunState(ctx, &data, run)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Download any files the run produced
+	outputFiles := []RunOutputFileModel{}
+	if !data.OutputDirectory.IsNull() && data.OutputDirectory.ValueString() != "" {
+		outputFiles, err = downloadRunOutputFiles(ctx, r.client, data.OutputDirectory.ValueString(), runMessages)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Downloading Run Output Files",
+				fmt.Sprintf("Unable to download files produced by run %s: %s", run.ID, err),
+			)
+			return
 		}
+	}
+	outputFilesList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: runOutputFileAttrTypes}, outputFiles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.OutputFiles = outputFilesList
 
-		// Poll until completion or timeout
-		startTime := time.Now()
-		for {
-			if time.Since(startTime) > timeout {
-				resp.Diagnostics.AddError(
-					"Run Timeout",
-					fmt.Sprintf("Run did not complete within %s", timeout),
-				)
-				return
-			}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
 
-			run, err = r.client.GetRun(ctx, run.ID, threadID)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error Getting Run",
-					fmt.Sprintf("Unable to get run: %s", err),
-				)
-				return
-			}
+func (r *RunResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RunResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-			switch run.Status {
-			case openai.RunStatusCompleted:
-				goto DONE
-			case openai.RunStatusFailed, openai.RunStatusExpired, openai.RunStatusCancelled:
-				resp.Diagnostics.AddError(
-					"Run Failed",
-					fmt.Sprintf("Run failed with status %s: %v", run.Status, run.LastError),
-				)
-				return
-			case openai.RunStatusRequiresAction:
-				resp.Diagnostics.AddError(
-					"Run Requires Action",
-					"Run requires action but automatic tool outputs are not supported",
-				)
-				return
-			default:
-				time.Sleep(pollingInterval)
-				continue
-			}
+	run, err := r.client.GetRun(ctx, data.ID.ValueString(), data.ThreadID.ValueString())
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			resp.State.RemoveResource(ctx)
+			return
 		}
-	DONE:
+		resp.Diagnostics.AddError(
+			"Error Reading Run",
+			fmt.Sprintf("Unable to read run: %s", err),
+		)
+		return
 	}
 
-	// Update Terraform state
-	data.ID = types.StringValue(run.ID)
-	data.Status = types.StringValue(string(run.Status))
-	data.CreatedAt = types.Int64Value(run.CreatedAt)
-	data.ThreadID = types.StringValue(run.ThreadID)
-	data.AssistantID = types.StringValue(run.AssistantID)
+	// An imported run has no status yet
+	imported := data.Status.IsNull()
+
+	// Update state with latest data. Output files are only downloaded on
+	// create, so output_files is kept from state.
+	_, diags := r.setRunState(ctx, &data, run)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if imported {
+		// An imported run has no downloaded files
+		data.OutputFiles = types.ListValueMust(types.ObjectType{AttrTypes: runOutputFileAttrTypes}, []attr.Value{})
+		// truncation_strategy is a block and cannot be computed, so it is
+		// only restored on import and only when the run did not use the
+		// default strategy
+		data.TruncationStrategy = flattenTruncationStrategy(run.TruncationStrategy)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// abortRun cancels a run that was interrupted or timed out while Create was
+// waiting for it and saves it to state as tainted
+func (r *RunResource) abortRun(ctx context.Context, data *RunResourceModel, run *openai.Run, timeout time.Duration, resp *resource.CreateResponse) {
+	abortRun(ctx, r.client, run, timeout, "run", resp, func(ctx context.Context, final *openai.Run) diag.Diagnostics {
+		// Output files are only downloaded once a run completes
+		data.OutputFiles = types.ListValueMust(types.ObjectType{AttrTypes: runOutputFileAttrTypes}, []attr.Value{})
+
+		_, diags := r.setRunState(ctx, data, final)
+		diags.Append(resp.State.Set(ctx, data)...)
+		return diags
+	})
+}
+
+// setRunState records everything the API reports about a run in the model and
+// returns the messages the run created
+func (r *RunResource) setRunState(ctx context.Context, data *RunResourceModel, run *openai.Run) ([]client.Message, diag.Diagnostics) {
+	state, diags := flattenRunState(ctx, r.client, run)
+	data.ID = state.ID
+	data.ThreadID = state.ThreadID
+	data.AssistantID = state.AssistantID
+	data.Status = state.Status
+	data.CreatedAt = state.CreatedAt
+	data.CompletedAt = state.CompletedAt
+	data.LastError = state.LastError
+	data.IncompleteDetails = state.IncompleteDetails
+	data.Usage = state.Usage
+	data.Steps = state.Steps
+	data.ResponseContent = state.ResponseContent
+	data.ResponseMessages = state.ResponseMessages
 
 	// Initialize computed fields with empty/zero values if not set
 	if run.ExpiresAt > 0 {
@@ -431,29 +683,6 @@ func (r *RunResource) Create(ctx context.Context, req resource.CreateRequest, re
 		data.FailedAt = types.Int64Value(0)
 	}
 
-	if run.CompletedAt != nil && *run.CompletedAt > 0 {
-		data.CompletedAt = types.Int64Value(*run.CompletedAt)
-	} else {
-		data.CompletedAt = types.Int64Value(0)
-	}
-
-	if run.LastError != nil {
-		data.LastError = types.StringValue(run.LastError.Message)
-	} else {
-		data.LastError = types.StringValue("")
-	}
-
-	if run.Status == openai.RunStatusIncomplete {
-		data.IncompleteDetails = types.StringValue("Run was marked incomplete due to token limit")
-	} else {
-		data.IncompleteDetails = types.StringValue("")
-	}
-
-	// Initialize these fields as empty strings if they're not set
-	if data.ResponseContent.IsNull() {
-		data.ResponseContent = types.StringValue("")
-	}
-
 	// Initialize max tokens fields
 	if run.MaxPromptTokens > 0 {
 		data.MaxPromptTokens = types.Int64Value(int64(run.MaxPromptTokens))
@@ -471,7 +700,7 @@ func (r *RunResource) Create(ctx context.Context, req resource.CreateRequest, re
 	emptyReqAction := make(map[string]attr.Value)
 	emptyReqAction["type"] = types.StringValue("")
 	emptySubmitOutputs := make(map[string]attr.Value)
-	emptyToolCalls, diags := types.ListValueFrom(ctx, types.ObjectType{
+	emptyToolCalls, d := types.ListValueFrom(ctx, types.ObjectType{
 		AttrTypes: map[string]attr.Type{
 			"id":   types.StringType,
 			"type": types.StringType,
@@ -483,9 +712,9 @@ func (r *RunResource) Create(ctx context.Context, req resource.CreateRequest, re
 			},
 		},
 	}, []interface{}{})
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
 	}
 	emptySubmitOutputs["tool_calls"] = emptyToolCalls
 	emptyReqAction["submit_tool_outputs"] = types.ObjectValueMust(
@@ -532,163 +761,105 @@ func (r *RunResource) Create(ctx context.Context, req resource.CreateRequest, re
 		emptyReqAction,
 	)
 
-	// Get response content from thread messages if run completed successfully
-	if run.Status == openai.RunStatusCompleted {
-		messages, err := r.client.OpenAI.ListMessage(ctx, data.ThreadID.ValueString(), nil, nil, nil, nil, nil)
-		if err == nil && len(messages.Messages) > 0 {
-			// Get the latest assistant message
-			for _, msg := range messages.Messages {
-				if msg.Role == "assistant" {
-					if len(msg.Content) > 0 {
-						data.ResponseContent = types.StringValue(msg.Content[0].Text.Value)
-						break
-					}
-				}
-			}
-		}
-	} else if run.Status == openai.RunStatusIncomplete {
-		data.IncompleteDetails = types.StringValue("Run was marked incomplete due to token limit")
+	if diags.HasError() {
+		return nil, diags
 	}
 
-	// Initialize these fields as empty strings if they're not set
-	if data.ResponseContent.IsNull() {
-		data.ResponseContent = types.StringValue("")
-	}
-	if data.IncompleteDetails.IsNull() {
-		data.IncompleteDetails = types.StringValue("")
+	diags.Append(r.restoreRunArguments(ctx, data, run)...)
+	if diags.HasError() {
+		return nil, diags
 	}
 
-	if run.ExpiresAt > 0 {
-		data.ExpiresAt = types.Int64Value(run.ExpiresAt)
-	}
+	return state.Messages, diags
+}
 
-	if run.StartedAt != nil && *run.StartedAt > 0 {
-		data.StartedAt = types.Int64Value(*run.StartedAt)
-	}
-	if run.CancelledAt != nil && *run.CancelledAt > 0 {
-		data.CancelledAt = types.Int64Value(*run.CancelledAt)
-	}
-	if run.FailedAt != nil && *run.FailedAt > 0 {
-		data.FailedAt = types.Int64Value(*run.FailedAt)
-	}
-	if run.CompletedAt != nil && *run.CompletedAt > 0 {
-		data.CompletedAt = types.Int64Value(*run.CompletedAt)
-	}
+// restoreRunArguments fills the arguments left unset in data with the values
+// the run was created with, so a run that was imported or that uses the
+// assistant's defaults plans no changes. Configured arguments are kept.
+func (r *RunResource) restoreRunArguments(ctx context.Context, data *RunResourceModel, run *openai.Run) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	if run.LastError != nil {
-		data.LastError = types.StringValue(run.LastError.Message)
+	if data.Model.IsNull() || data.Model.IsUnknown() {
+		data.Model = types.StringValue(run.Model)
 	}
 
-	// For now, we'll set an empty steps list since the API doesn't provide step IDs yet
-	stepsList, diags := types.ListValueFrom(ctx, types.StringType, []string{})
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	if data.Instructions.IsNull() || data.Instructions.IsUnknown() {
+		data.Instructions = types.StringValue(run.Instructions)
 	}
-	data.Steps = stepsList
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
+	if data.Tools.IsNull() || data.Tools.IsUnknown() {
+		toolTypes := make([]string, 0, len(run.Tools))
+		for _, tool := range run.Tools {
+			toolTypes = append(toolTypes, string(tool.Type))
+		}
+		tools, d := types.ListValueFrom(ctx, types.StringType, toolTypes)
+		diags.Append(d...)
+		data.Tools = tools
+	}
+
+	if data.Metadata.IsNull() || data.Metadata.IsUnknown() {
+		stringMetadata := make(map[string]string, len(run.Metadata))
+		for k, v := range run.Metadata {
+			if strVal, ok := v.(string); ok {
+				stringMetadata[k] = strVal
+			} else {
+				stringMetadata[k] = fmt.Sprintf("%v", v)
+			}
+		}
+		metadata, d := types.MapValueFrom(ctx, types.StringType, stringMetadata)
+		diags.Append(d...)
+		data.Metadata = metadata
+	}
 
-func (r *RunResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data RunResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
+	// The go-openai Run type does not decode top_p or response_format, and
+	// decodes temperature as a float32 that does not round-trip
+	unset := func(v attr.Value) bool { return v.IsNull() || v.IsUnknown() }
+	if !unset(data.Temperature) && !unset(data.TopP) && !unset(data.ResponseFormat) {
+		return diags
 	}
 
-	run, err := r.client.GetRun(ctx, data.ID.ValueString(), data.ThreadID.ValueString())
+	settings, err := r.client.GetRunSettings(ctx, run.ThreadID, run.ID)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
-			resp.State.RemoveResource(ctx)
-			return
-		}
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error Reading Run",
-			fmt.Sprintf("Unable to read run: %s", err),
+			fmt.Sprintf("Unable to read the settings of run %s: %s", run.ID, err),
 		)
-		return
+		return diags
 	}
 
-	// Update state with latest data
-	data.Status = types.StringValue(string(run.Status))
-	data.CreatedAt = types.Int64Value(run.CreatedAt)
-	if run.ExpiresAt > 0 {
-		data.ExpiresAt = types.Int64Value(run.ExpiresAt)
-	} else {
-		data.ExpiresAt = types.Int64Value(0)
+	if unset(data.Temperature) {
+		data.Temperature = types.Float64PointerValue(settings.Temperature)
 	}
-	if run.StartedAt != nil {
-		data.StartedAt = types.Int64Value(*run.StartedAt)
-	} else {
-		data.StartedAt = types.Int64Value(0)
-	}
-	if run.CancelledAt != nil {
-		data.CancelledAt = types.Int64Value(*run.CancelledAt)
-	} else {
-		data.CancelledAt = types.Int64Value(0)
-	}
-	if run.FailedAt != nil {
-		data.FailedAt = types.Int64Value(*run.FailedAt)
-	} else {
-		data.FailedAt = types.Int64Value(0)
-	}
-	if run.CompletedAt != nil {
-		data.CompletedAt = types.Int64Value(*run.CompletedAt)
-	} else {
-		data.CompletedAt = types.Int64Value(0)
+	if unset(data.TopP) {
+		data.TopP = types.Float64PointerValue(settings.TopP)
 	}
-	if run.LastError != nil {
-		data.LastError = types.StringValue(run.LastError.Message)
-	} else {
-		data.LastError = types.StringValue("")
-	}
-
-	// Get response content from thread messages if run completed
-	if run.Status == openai.RunStatusCompleted {
-		messages, err := r.client.OpenAI.ListMessage(ctx, data.ThreadID.ValueString(), nil, nil, nil, nil, nil)
-		if err == nil && len(messages.Messages) > 0 {
-			// Get the latest assistant message
-			for _, msg := range messages.Messages {
-				if msg.Role == "assistant" {
-					if len(msg.Content) > 0 {
-						data.ResponseContent = types.StringValue(msg.Content[0].Text.Value)
-						break
-					}
-				}
-			}
+	if unset(data.ResponseFormat) {
+		if format := settings.ResponseFormatType(); format != "" {
+			data.ResponseFormat = types.StringValue(format)
+		} else {
+			data.ResponseFormat = types.StringNull()
 		}
 	}
 
-	// Set incomplete details if run was incomplete
-	if run.Status == openai.RunStatusIncomplete {
-		data.IncompleteDetails = types.StringValue("Run was marked incomplete due to token limit")
-	}
-
-	// Initialize these fields as empty strings if they're not set
-	if data.ResponseContent.IsNull() {
-		data.ResponseContent = types.StringValue("")
-	}
-	if data.IncompleteDetails.IsNull() {
-		data.IncompleteDetails = types.StringValue("")
-	}
+	return diags
+}
 
-	// Create empty steps list since the API doesn't expose steps
-	steps, diags := types.ListValueFrom(ctx, types.StringType, []string{})
-	resp.Diagnostics.Append(diags...)
+// Update only changes the wait settings, which have no effect once the run
+// exists. Every other argument forces a new run.
+func (r *RunResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state RunResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	data.Steps = steps
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
+	state.WaitForCompletion = plan.WaitForCompletion
+	state.Stream = plan.Stream
+	state.PollingInterval = plan.PollingInterval
+	state.Timeout = plan.Timeout
 
-func (r *RunResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"Runs cannot be updated after creation",
-	)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *RunResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -709,5 +880,16 @@ func (r *RunResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *RunResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Expected format: thread_id:run_id
+	idParts := strings.Split(req.ID, ":")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Error Importing Run",
+			"Invalid import ID format. Expected format: thread_id:run_id",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("thread_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
 }
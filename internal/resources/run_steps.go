@@ -0,0 +1,405 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sashabaranov/go-openai"
+)
+
+// RunUsageModel describes token usage for a run or a run step.
+type RunUsageModel struct {
+	PromptTokens     types.Int64 `tfsdk:"prompt_tokens"`
+	CompletionTokens types.Int64 `tfsdk:"completion_tokens"`
+	TotalTokens      types.Int64 `tfsdk:"total_tokens"`
+}
+
+// RunStepModel describes a single step taken during a run.
+type RunStepModel struct {
+	ID          types.String           `tfsdk:"id"`
+	Type        types.String           `tfsdk:"type"`
+	Status      types.String           `tfsdk:"status"`
+	CreatedAt   types.Int64            `tfsdk:"created_at"`
+	CompletedAt types.Int64            `tfsdk:"completed_at"`
+	MessageID   types.String           `tfsdk:"message_id"`
+	LastError   types.String           `tfsdk:"last_error"`
+	ToolCalls   []RunStepToolCallModel `tfsdk:"tool_calls"`
+	Usage       *RunUsageModel         `tfsdk:"usage"`
+}
+
+// RunStepToolCallModel describes a tool call made during a run step.
+type RunStepToolCallModel struct {
+	ID              types.String                 `tfsdk:"id"`
+	Type            types.String                 `tfsdk:"type"`
+	CodeInterpreter *RunStepCodeInterpreterModel `tfsdk:"code_interpreter"`
+	FileSearch      *RunStepFileSearchModel      `tfsdk:"file_search"`
+	Function        *RunStepFunctionModel        `tfsdk:"function"`
+}
+
+// RunStepCodeInterpreterModel describes a code interpreter tool call.
+type RunStepCodeInterpreterModel struct {
+	Input   types.String                        `tfsdk:"input"`
+	Outputs []RunStepCodeInterpreterOutputModel `tfsdk:"outputs"`
+}
+
+// RunStepCodeInterpreterOutputModel describes a single code interpreter output.
+type RunStepCodeInterpreterOutputModel struct {
+	Type        types.String `tfsdk:"type"`
+	Logs        types.String `tfsdk:"logs"`
+	ImageFileID types.String `tfsdk:"image_file_id"`
+}
+
+// RunStepFileSearchModel describes a file search tool call.
+type RunStepFileSearchModel struct {
+	Results []RunStepFileSearchResultModel `tfsdk:"results"`
+}
+
+// RunStepFileSearchResultModel describes a single file search result.
+type RunStepFileSearchResultModel struct {
+	FileID   types.String  `tfsdk:"file_id"`
+	FileName types.String  `tfsdk:"file_name"`
+	Score    types.Float64 `tfsdk:"score"`
+}
+
+// RunStepFunctionModel describes a function tool call.
+type RunStepFunctionModel struct {
+	Name      types.String `tfsdk:"name"`
+	Arguments types.String `tfsdk:"arguments"`
+	Output    types.String `tfsdk:"output"`
+}
+
+var runUsageAttrTypes = map[string]attr.Type{
+	"prompt_tokens":     types.Int64Type,
+	"completion_tokens": types.Int64Type,
+	"total_tokens":      types.Int64Type,
+}
+
+var runStepAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"type":         types.StringType,
+	"status":       types.StringType,
+	"created_at":   types.Int64Type,
+	"completed_at": types.Int64Type,
+	"message_id":   types.StringType,
+	"last_error":   types.StringType,
+	"tool_calls": types.ListType{
+		ElemType: types.ObjectType{
+			AttrTypes: map[string]attr.Type{
+				"id":   types.StringType,
+				"type": types.StringType,
+				"code_interpreter": types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"input": types.StringType,
+						"outputs": types.ListType{
+							ElemType: types.ObjectType{
+								AttrTypes: map[string]attr.Type{
+									"type":          types.StringType,
+									"logs":          types.StringType,
+									"image_file_id": types.StringType,
+								},
+							},
+						},
+					},
+				},
+				"file_search": types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"results": types.ListType{
+							ElemType: types.ObjectType{
+								AttrTypes: map[string]attr.Type{
+									"file_id":   types.StringType,
+									"file_name": types.StringType,
+									"score":     types.Float64Type,
+								},
+							},
+						},
+					},
+				},
+				"function": types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"name":      types.StringType,
+						"arguments": types.StringType,
+						"output":    types.StringType,
+					},
+				},
+			},
+		},
+	},
+	"usage": types.ObjectType{
+		AttrTypes: runUsageAttrTypes,
+	},
+}
+
+// runUsageSchemaAttributes returns the computed attributes of a usage object.
+func runUsageSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"prompt_tokens": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Number of prompt tokens used.",
+		},
+		"completion_tokens": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Number of completion tokens used.",
+		},
+		"total_tokens": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Total number of tokens used.",
+		},
+	}
+}
+
+// runStepsSchemaAttribute returns the computed steps attribute shared by run resources.
+func runStepsSchemaAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "The steps taken during the run, in the order they were created.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The ID of the run step.",
+				},
+				"type": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The type of run step, either message_creation or tool_calls.",
+				},
+				"status": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The status of the run step.",
+				},
+				"created_at": schema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "Unix timestamp for when the run step was created.",
+				},
+				"completed_at": schema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "Unix timestamp for when the run step completed.",
+				},
+				"message_id": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The ID of the message created by a message_creation step.",
+				},
+				"last_error": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The last error message if the run step failed.",
+				},
+				"tool_calls": schema.ListNestedAttribute{
+					Computed:            true,
+					MarkdownDescription: "The tool calls made by a tool_calls step.",
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"id": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "The ID of the tool call.",
+							},
+							"type": schema.StringAttribute{
+								Computed:            true,
+								MarkdownDescription: "The type of tool call: code_interpreter, file_search or function.",
+							},
+							"code_interpreter": schema.SingleNestedAttribute{
+								Computed:            true,
+								MarkdownDescription: "Details of a code interpreter tool call.",
+								Attributes: map[string]schema.Attribute{
+									"input": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The code that was run.",
+									},
+									"outputs": schema.ListNestedAttribute{
+										Computed:            true,
+										MarkdownDescription: "The outputs of the code interpreter call.",
+										NestedObject: schema.NestedAttributeObject{
+											Attributes: map[string]schema.Attribute{
+												"type": schema.StringAttribute{
+													Computed:            true,
+													MarkdownDescription: "The type of output, either logs or image.",
+												},
+												"logs": schema.StringAttribute{
+													Computed:            true,
+													MarkdownDescription: "The text output of a logs output.",
+												},
+												"image_file_id": schema.StringAttribute{
+													Computed:            true,
+													MarkdownDescription: "The file ID of an image output.",
+												},
+											},
+										},
+									},
+								},
+							},
+							"file_search": schema.SingleNestedAttribute{
+								Computed:            true,
+								MarkdownDescription: "Details of a file search tool call.",
+								Attributes: map[string]schema.Attribute{
+									"results": schema.ListNestedAttribute{
+										Computed:            true,
+										MarkdownDescription: "The results of the file search.",
+										NestedObject: schema.NestedAttributeObject{
+											Attributes: map[string]schema.Attribute{
+												"file_id": schema.StringAttribute{
+													Computed:            true,
+													MarkdownDescription: "The ID of the file the result was found in.",
+												},
+												"file_name": schema.StringAttribute{
+													Computed:            true,
+													MarkdownDescription: "The name of the file the result was found in.",
+												},
+												"score": schema.Float64Attribute{
+													Computed:            true,
+													MarkdownDescription: "The relevance score of the result.",
+												},
+											},
+										},
+									},
+								},
+							},
+							"function": schema.SingleNestedAttribute{
+								Computed:            true,
+								MarkdownDescription: "Details of a function tool call.",
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The name of the function.",
+									},
+									"arguments": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The JSON arguments passed to the function.",
+									},
+									"output": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The output submitted for the function, if any.",
+									},
+								},
+							},
+						},
+					},
+				},
+				"usage": schema.SingleNestedAttribute{
+					Computed:            true,
+					MarkdownDescription: "Token usage for the run step.",
+					Attributes:          runUsageSchemaAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// runUsage returns the token usage of a run, or nil until the run reaches a terminal status.
+func runUsage(run *openai.Run) *client.RunUsage {
+	if run.Usage == (openai.Usage{}) {
+		return nil
+	}
+	return &client.RunUsage{
+		PromptTokens:     run.Usage.PromptTokens,
+		CompletionTokens: run.Usage.CompletionTokens,
+		TotalTokens:      run.Usage.TotalTokens,
+	}
+}
+
+// flattenRunUsage converts API token usage into a Terraform usage object.
+func flattenRunUsage(ctx context.Context, usage *client.RunUsage) (types.Object, diag.Diagnostics) {
+	if usage == nil {
+		return types.ObjectNull(runUsageAttrTypes), nil
+	}
+	return types.ObjectValueFrom(ctx, runUsageAttrTypes, RunUsageModel{
+		PromptTokens:     types.Int64Value(int64(usage.PromptTokens)),
+		CompletionTokens: types.Int64Value(int64(usage.CompletionTokens)),
+		TotalTokens:      types.Int64Value(int64(usage.TotalTokens)),
+	})
+}
+
+// flattenRunSteps converts API run steps into the Terraform steps list.
+func flattenRunSteps(ctx context.Context, steps []client.RunStep) (types.List, diag.Diagnostics) {
+	models := make([]RunStepModel, 0, len(steps))
+	for _, step := range steps {
+		model := RunStepModel{
+			ID:          types.StringValue(step.ID),
+			Type:        types.StringValue(step.Type),
+			Status:      types.StringValue(step.Status),
+			CreatedAt:   types.Int64Value(step.CreatedAt),
+			CompletedAt: types.Int64Value(0),
+			MessageID:   types.StringNull(),
+			LastError:   types.StringNull(),
+			ToolCalls:   []RunStepToolCallModel{},
+		}
+		if step.CompletedAt != nil {
+			model.CompletedAt = types.Int64Value(*step.CompletedAt)
+		}
+		if step.StepDetails.MessageCreation != nil {
+			model.MessageID = types.StringValue(step.StepDetails.MessageCreation.MessageID)
+		}
+		if step.LastError != nil {
+			model.LastError = types.StringValue(step.LastError.Message)
+		}
+		if step.Usage != nil {
+			model.Usage = &RunUsageModel{
+				PromptTokens:     types.Int64Value(int64(step.Usage.PromptTokens)),
+				CompletionTokens: types.Int64Value(int64(step.Usage.CompletionTokens)),
+				TotalTokens:      types.Int64Value(int64(step.Usage.TotalTokens)),
+			}
+		}
+
+		for _, call := range step.StepDetails.ToolCalls {
+			callModel := RunStepToolCallModel{
+				ID:   types.StringValue(call.ID),
+				Type: types.StringValue(call.Type),
+			}
+
+			if call.CodeInterpreter != nil {
+				ci := &RunStepCodeInterpreterModel{
+					Input:   types.StringValue(call.CodeInterpreter.Input),
+					Outputs: []RunStepCodeInterpreterOutputModel{},
+				}
+				for _, output := range call.CodeInterpreter.Outputs {
+					outputModel := RunStepCodeInterpreterOutputModel{
+						Type:        types.StringValue(output.Type),
+						Logs:        types.StringNull(),
+						ImageFileID: types.StringNull(),
+					}
+					if output.Logs != "" {
+						outputModel.Logs = types.StringValue(output.Logs)
+					}
+					if output.Image != nil {
+						outputModel.ImageFileID = types.StringValue(output.Image.FileID)
+					}
+					ci.Outputs = append(ci.Outputs, outputModel)
+				}
+				callModel.CodeInterpreter = ci
+			}
+
+			if call.FileSearch != nil {
+				fs := &RunStepFileSearchModel{
+					Results: []RunStepFileSearchResultModel{},
+				}
+				for _, result := range call.FileSearch.Results {
+					fs.Results = append(fs.Results, RunStepFileSearchResultModel{
+						FileID:   types.StringValue(result.FileID),
+						FileName: types.StringValue(result.FileName),
+						Score:    types.Float64Value(result.Score),
+					})
+				}
+				callModel.FileSearch = fs
+			}
+
+			if call.Function != nil {
+				fn := &RunStepFunctionModel{
+					Name:      types.StringValue(call.Function.Name),
+					Arguments: types.StringValue(call.Function.Arguments),
+					Output:    types.StringNull(),
+				}
+				if call.Function.Output != nil {
+					fn.Output = types.StringValue(*call.Function.Output)
+				}
+				callModel.Function = fn
+			}
+
+			model.ToolCalls = append(model.ToolCalls, callModel)
+		}
+
+		models = append(models, model)
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: runStepAttrTypes}, models)
+}
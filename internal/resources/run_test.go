@@ -0,0 +1,318 @@
+package resources_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darnold/terraform-provider-openai/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testImportedRun is a completed run created with every argument the API
+// reports back
+const testImportedRun = `{
+	"id": "run_1", "object": "thread.run", "thread_id": "thread_1", "assistant_id": "asst_1",
+	"status": "completed", "created_at": 1700000000, "completed_at": 1700000060,
+	"model": "gpt-4o", "instructions": "Be brief.",
+	"tools": [{"type": "code_interpreter"}, {"type": "file_search"}],
+	"metadata": {"team": "data"},
+	"temperature": 0.2, "top_p": 0.9, "response_format": {"type": "json_object"},
+	"truncation_strategy": {"type": "last_messages", "last_messages": 5},
+	"max_prompt_tokens": 1000, "max_completion_tokens": 500
+}`
+
+// runConfig returns an openai_run configuration for thread_1 and asst_1 with
+// the given arguments, as Terraform sends it to the provider
+func runConfig(schema *tfprotov6.Schema, attrs map[string]tftypes.Value) tftypes.Value {
+	objType := schema.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{
+		"thread_id":    tftypes.NewValue(tftypes.String, "thread_1"),
+		"assistant_id": tftypes.NewValue(tftypes.String, "asst_1"),
+	}
+	for name, value := range attrs {
+		values[name] = value
+	}
+	for name, typ := range objType.AttributeTypes {
+		if _, ok := values[name]; !ok {
+			values[name] = tftypes.NewValue(typ, nil)
+		}
+	}
+	// Terraform sends an absent list block as an empty list
+	if _, ok := attrs["additional_messages"]; !ok {
+		values["additional_messages"] = tftypes.NewValue(objType.AttributeTypes["additional_messages"], []tftypes.Value{})
+	}
+	return tftypes.NewValue(objType, values)
+}
+
+// proposedNewState merges config into the prior state the way Terraform does
+// before planning: computed attributes left out of config keep their prior value
+func proposedNewState(t *testing.T, schema *tfprotov6.Schema, prior, config tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	var priorValues, configValues map[string]tftypes.Value
+	if err := prior.As(&priorValues); err != nil {
+		t.Fatalf("decoding prior state: %s", err)
+	}
+	if err := config.As(&configValues); err != nil {
+		t.Fatalf("decoding config: %s", err)
+	}
+
+	proposed := make(map[string]tftypes.Value, len(configValues))
+	for name, value := range configValues {
+		proposed[name] = value
+	}
+	for _, attr := range schema.Block.Attributes {
+		if attr.Computed && configValues[attr.Name].IsNull() {
+			proposed[attr.Name] = priorValues[attr.Name]
+		}
+	}
+	return tftypes.NewValue(config.Type(), proposed)
+}
+
+// testProviderServer returns a configured provider server that sends its
+// requests to baseURL, along with its schemas
+func testProviderServer(t *testing.T, baseURL string) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(provider.New("test")())()
+	if err != nil {
+		t.Fatalf("creating provider server: %s", err)
+	}
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil || len(schemas.Diagnostics) > 0 {
+		t.Fatalf("reading schemas: %v %v", err, schemas.Diagnostics)
+	}
+	providerType := schemas.Provider.ValueType()
+	providerConfig, err := tfprotov6.NewDynamicValue(providerType, tftypes.NewValue(providerType, map[string]tftypes.Value{
+		"api_key":              tftypes.NewValue(tftypes.String, "test-key"),
+		"base_url":             tftypes.NewValue(tftypes.String, baseURL),
+		"organization":         tftypes.NewValue(tftypes.String, nil),
+		"enable_debug_logging": tftypes.NewValue(tftypes.Bool, nil),
+	}))
+	if err != nil {
+		t.Fatalf("encoding provider config: %s", err)
+	}
+	configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &providerConfig})
+	if err != nil || len(configured.Diagnostics) > 0 {
+		t.Fatalf("configuring provider: %v %v", err, configured.Diagnostics)
+	}
+
+	return server, schemas
+}
+
+// truncationStrategyValue returns a truncation_strategy block
+func truncationStrategyValue(strategyType, lastMessages interface{}) tftypes.Value {
+	return tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"type":          tftypes.String,
+		"last_messages": tftypes.Number,
+	}}, map[string]tftypes.Value{
+		"type":          tftypes.NewValue(tftypes.String, strategyType),
+		"last_messages": tftypes.NewValue(tftypes.Number, lastMessages),
+	})
+}
+
+func TestRunResourceValidateTruncationStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy tftypes.Value
+		wantPath string
+		wantErr  string
+	}{
+		{
+			name:     "auto",
+			strategy: truncationStrategyValue("auto", nil),
+		},
+		{
+			name:     "last messages",
+			strategy: truncationStrategyValue("last_messages", 10),
+		},
+		{
+			name:     "missing type",
+			strategy: truncationStrategyValue(nil, 10),
+			wantPath: "truncation_strategy.type",
+			wantErr:  "Missing Configuration for Required Attribute",
+		},
+		{
+			name:     "unknown type",
+			strategy: truncationStrategyValue("first_messages", nil),
+			wantPath: "truncation_strategy.type",
+			wantErr:  `type must be either auto or last_messages, got "first_messages"`,
+		},
+		{
+			name:     "last_messages with auto",
+			strategy: truncationStrategyValue("auto", 10),
+			wantPath: "truncation_strategy.last_messages",
+			wantErr:  "last_messages can only be set when type is last_messages",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, schemas := testProviderServer(t, "http://127.0.0.1:0")
+			schema := schemas.ResourceSchemas["openai_run"]
+
+			config, err := tfprotov6.NewDynamicValue(schema.ValueType(), runConfig(schema, map[string]tftypes.Value{
+				"truncation_strategy": tt.strategy,
+			}))
+			if err != nil {
+				t.Fatalf("encoding config: %s", err)
+			}
+
+			resp, err := server.ValidateResourceConfig(context.Background(), &tfprotov6.ValidateResourceConfigRequest{
+				TypeName: "openai_run",
+				Config:   &config,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if tt.wantErr == "" {
+				if len(resp.Diagnostics) > 0 {
+					t.Fatalf("got diagnostics %+v, want none", resp.Diagnostics[0])
+				}
+				return
+			}
+			if len(resp.Diagnostics) != 1 {
+				t.Fatalf("got %d diagnostics, want 1", len(resp.Diagnostics))
+			}
+			diag := resp.Diagnostics[0]
+			if !strings.Contains(diag.Summary+" "+diag.Detail, tt.wantErr) {
+				t.Errorf("got diagnostic %q: %q, want one containing %q", diag.Summary, diag.Detail, tt.wantErr)
+			}
+			if diag.Attribute == nil || attributePathString(diag.Attribute) != tt.wantPath {
+				t.Errorf("got diagnostic at %v, want %s", diag.Attribute, tt.wantPath)
+			}
+		})
+	}
+}
+
+// attributePathString renders an attribute path as dot-separated names
+func attributePathString(p *tftypes.AttributePath) string {
+	var names []string
+	for _, step := range p.Steps() {
+		if name, ok := step.(tftypes.AttributeName); ok {
+			names = append(names, string(name))
+		}
+	}
+	return strings.Join(names, ".")
+}
+
+func TestRunResourceImportPlansNoChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]tftypes.Value
+	}{
+		{
+			name: "arguments left out",
+			config: map[string]tftypes.Value{
+				"truncation_strategy": truncationStrategyValue("last_messages", 5),
+			},
+		},
+		{
+			name: "arguments matching the run",
+			config: map[string]tftypes.Value{
+				"model":        tftypes.NewValue(tftypes.String, "gpt-4o"),
+				"instructions": tftypes.NewValue(tftypes.String, "Be brief."),
+				"tools": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "code_interpreter"),
+					tftypes.NewValue(tftypes.String, "file_search"),
+				}),
+				"metadata": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"team": tftypes.NewValue(tftypes.String, "data"),
+				}),
+				"temperature":         tftypes.NewValue(tftypes.Number, 0.2),
+				"top_p":               tftypes.NewValue(tftypes.Number, 0.9),
+				"response_format":     tftypes.NewValue(tftypes.String, "json_object"),
+				"max_prompt_tokens":   tftypes.NewValue(tftypes.Number, 1000),
+				"truncation_strategy": truncationStrategyValue("last_messages", 5),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/threads/thread_1/runs/run_1":
+					fmt.Fprint(w, testImportedRun)
+				case "/threads/thread_1/messages", "/threads/thread_1/runs/run_1/steps":
+					fmt.Fprint(w, `{"object": "list", "data": [], "has_more": false}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			server6, schemas := testProviderServer(t, server.URL)
+			schema := schemas.ResourceSchemas["openai_run"]
+			stateType := schema.ValueType()
+
+			imported, err := server6.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+				TypeName: "openai_run",
+				ID:       "thread_1:run_1",
+			})
+			if err != nil || len(imported.Diagnostics) > 0 || len(imported.ImportedResources) != 1 {
+				t.Fatalf("importing run: %v %v", err, imported.Diagnostics)
+			}
+
+			read, err := server6.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName:     "openai_run",
+				CurrentState: imported.ImportedResources[0].State,
+			})
+			if err != nil || len(read.Diagnostics) > 0 {
+				t.Fatalf("reading run: %v %v", err, read.Diagnostics)
+			}
+			prior, err := read.NewState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatalf("decoding state: %s", err)
+			}
+
+			config := runConfig(schema, tt.config)
+			proposed := proposedNewState(t, schema, prior, config)
+			configValue, err := tfprotov6.NewDynamicValue(stateType, config)
+			if err != nil {
+				t.Fatalf("encoding config: %s", err)
+			}
+			proposedValue, err := tfprotov6.NewDynamicValue(stateType, proposed)
+			if err != nil {
+				t.Fatalf("encoding proposed state: %s", err)
+			}
+
+			plan, err := server6.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "openai_run",
+				PriorState:       read.NewState,
+				ProposedNewState: &proposedValue,
+				Config:           &configValue,
+			})
+			if err != nil || len(plan.Diagnostics) > 0 {
+				t.Fatalf("planning: %v %v", err, plan.Diagnostics)
+			}
+			if len(plan.RequiresReplace) > 0 {
+				t.Errorf("got replacement forced by %v, want none", plan.RequiresReplace)
+			}
+
+			planned, err := plan.PlannedState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatalf("decoding planned state: %s", err)
+			}
+			diffs, err := prior.Diff(planned)
+			if err != nil {
+				t.Fatalf("comparing states: %s", err)
+			}
+			for _, diff := range diffs {
+				t.Errorf("got change at %s: %v -> %v", diff.Path, diff.Value1, diff.Value2)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sashabaranov/go-openai"
+)
+
+const (
+	// runCancelTimeout bounds how long an interrupted Create waits for a run to be cancelled
+	runCancelTimeout = time.Minute
+	// runCancelPollInterval is how often a cancelling run is polled
+	runCancelPollInterval = time.Second
+)
+
+// waitForRun polls a run until it completes. It returns the last run it saw
+// and whether waiting stopped because timeout elapsed since startTime or ctx
+// was cancelled, in which case the caller should abort the run. A run that
+// fails, expires, is cancelled or requires action is reported as an error.
+func waitForRun(ctx context.Context, c *client.Client, run *openai.Run, pollingInterval, timeout time.Duration, startTime time.Time) (*openai.Run, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	for {
+		switch run.Status {
+		case openai.RunStatusCompleted, openai.RunStatusIncomplete:
+			return run, false, diags
+		case openai.RunStatusFailed, openai.RunStatusExpired, openai.RunStatusCancelled:
+			diags.AddError(
+				"Run Failed",
+				fmt.Sprintf("Run failed with status %s: %v", run.Status, run.LastError),
+			)
+			return run, false, diags
+		case openai.RunStatusRequiresAction:
+			diags.AddError(
+				"Run Requires Action",
+				"Run requires action but automatic tool outputs are not supported",
+			)
+			return run, false, diags
+		}
+
+		if ctx.Err() != nil || time.Since(startTime) > timeout {
+			return run, true, diags
+		}
+
+		select {
+		case <-ctx.Done():
+			continue
+		case <-time.After(pollingInterval):
+		}
+
+		current, err := c.GetRun(ctx, run.ID, run.ThreadID)
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			diags.AddError(
+				"Error Getting Run",
+				fmt.Sprintf("Unable to get run: %s", err),
+			)
+			return run, false, diags
+		}
+		run = current
+	}
+}
+
+// abortRun cancels a run that was interrupted or timed out while Create was
+// waiting for it, rather than leave it in progress server-side where it would
+// keep consuming tokens and lock the thread. The final run is passed to save,
+// which records it in state alongside the error so Terraform marks the
+// resource as tainted. replaced names what the next apply replaces.
+func abortRun(ctx context.Context, c *client.Client, run *openai.Run, timeout time.Duration, replaced string, resp *resource.CreateResponse, save func(context.Context, *openai.Run) diag.Diagnostics) {
+	summary := "Run Timeout"
+	reason := fmt.Sprintf("Run %s did not complete within %s", run.ID, timeout)
+	if ctx.Err() != nil {
+		summary = "Run Interrupted"
+		reason = fmt.Sprintf("Terraform was interrupted before run %s completed", run.ID)
+	}
+
+	// The Create context may already be cancelled, so cancelling the run and
+	// saving state use a context of their own
+	cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), runCancelTimeout)
+	defer cancel()
+
+	final, err := cancelRunAndWait(cleanupCtx, c, run)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			summary,
+			fmt.Sprintf("%s and could not be cancelled: %s. Last known status: %s.", reason, err, final.Status),
+		)
+	} else {
+		resp.Diagnostics.AddError(
+			summary,
+			fmt.Sprintf("%s and was cancelled. Final status: %s. The %s has been saved as tainted and will be replaced on the next apply.", reason, final.Status, replaced),
+		)
+	}
+
+	resp.Diagnostics.Append(save(cleanupCtx, final)...)
+}
+
+// cancelRunAndWait cancels a run and polls it until it reaches a terminal
+// status, returning the last run it saw
+func cancelRunAndWait(ctx context.Context, c *client.Client, run *openai.Run) (*openai.Run, error) {
+	cancelErr := c.CancelRun(ctx, run.ID, run.ThreadID)
+
+	for {
+		current, err := c.GetRun(ctx, run.ID, run.ThreadID)
+		if err != nil {
+			return run, err
+		}
+		run = current
+
+		switch run.Status {
+		case openai.RunStatusCancelled, openai.RunStatusCompleted, openai.RunStatusIncomplete,
+			openai.RunStatusFailed, openai.RunStatusExpired:
+			// A run that finished on its own can no longer be cancelled
+			return run, nil
+		}
+
+		if cancelErr != nil {
+			return run, cancelErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return run, fmt.Errorf("run was still %s after %s", run.Status, runCancelTimeout)
+		case <-time.After(runCancelPollInterval):
+		}
+	}
+}
+
+// runState holds the values both run resources report about a run
+type runState struct {
+	ID                types.String
+	ThreadID          types.String
+	AssistantID       types.String
+	Status            types.String
+	CreatedAt         types.Int64
+	CompletedAt       types.Int64
+	LastError         types.String
+	IncompleteDetails types.String
+	Usage             types.Object
+	Steps             types.List
+	ResponseContent   types.String
+	ResponseMessages  types.List
+	// Messages are the messages the run created
+	Messages []client.Message
+}
+
+// flattenRunState reads a run's messages and steps and converts everything
+// the API reports about the run into state values. Values that cannot be
+// read are left null so state stays known.
+func flattenRunState(ctx context.Context, c *client.Client, run *openai.Run) (runState, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	state := runState{
+		ID:                types.StringValue(run.ID),
+		ThreadID:          types.StringValue(run.ThreadID),
+		AssistantID:       types.StringValue(run.AssistantID),
+		Status:            types.StringValue(string(run.Status)),
+		CreatedAt:         types.Int64Value(run.CreatedAt),
+		CompletedAt:       types.Int64Value(0),
+		LastError:         types.StringValue(""),
+		IncompleteDetails: types.StringValue(""),
+		Steps:             types.ListNull(types.ObjectType{AttrTypes: runStepAttrTypes}),
+		ResponseContent:   types.StringValue(""),
+		ResponseMessages:  types.ListNull(types.ObjectType{AttrTypes: runResponseMessageAttrTypes}),
+	}
+
+	if run.CompletedAt != nil && *run.CompletedAt > 0 {
+		state.CompletedAt = types.Int64Value(*run.CompletedAt)
+	}
+	if run.LastError != nil {
+		state.LastError = types.StringValue(run.LastError.Message)
+	}
+	if run.Status == openai.RunStatusIncomplete {
+		state.IncompleteDetails = types.StringValue("Run was marked incomplete due to token limit")
+	}
+
+	usage, d := flattenRunUsage(ctx, runUsage(run))
+	diags.Append(d...)
+	state.Usage = usage
+
+	messages, err := c.ListMessages(ctx, run.ThreadID, client.ListMessagesOptions{
+		RunID: run.ID,
+	})
+	if err != nil {
+		diags.AddError(
+			"Error Listing Run Messages",
+			fmt.Sprintf("Unable to list messages for run %s: %s", run.ID, err),
+		)
+		return state, diags
+	}
+	list, text, d := flattenRunMessages(ctx, messages)
+	diags.Append(d...)
+	if diags.HasError() {
+		return state, diags
+	}
+	state.Messages = messages
+	state.ResponseMessages = list
+	state.ResponseContent = types.StringValue(text)
+
+	steps, err := c.ListRunSteps(ctx, run.ThreadID, run.ID)
+	if err != nil {
+		diags.AddError(
+			"Error Listing Run Steps",
+			fmt.Sprintf("Unable to list steps for run %s: %s", run.ID, err),
+		)
+		return state, diags
+	}
+	stepsList, d := flattenRunSteps(ctx, steps)
+	diags.Append(d...)
+	if diags.HasError() {
+		return state, diags
+	}
+	state.Steps = stepsList
+
+	return state, diags
+}
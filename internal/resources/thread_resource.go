@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -43,10 +44,12 @@ type ThreadResourceModel struct {
 }
 
 type ThreadMessageModel struct {
-	Role     types.String `tfsdk:"role"`
-	Content  types.String `tfsdk:"content"`
-	FileIDs  types.List   `tfsdk:"file_ids"`
-	Metadata types.Map    `tfsdk:"metadata"`
+	ID          types.String      `tfsdk:"id"`
+	Role        types.String      `tfsdk:"role"`
+	Content     types.String      `tfsdk:"content"`
+	Attachments []AttachmentModel `tfsdk:"attachment"`
+	FileIDs     types.List        `tfsdk:"file_ids"`
+	Metadata    types.Map         `tfsdk:"metadata"`
 }
 
 type ThreadToolResourcesModel struct {
@@ -110,16 +113,26 @@ func (r *ThreadResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							"vector_store_ids": types.SetType{
 								ElemType: types.StringType,
 							},
-							},
 						},
 					},
 				},
 			},
+		},
 		Blocks: map[string]schema.Block{
 			"messages": schema.ListNestedBlock{
-				MarkdownDescription: "Initial messages for the thread.",
+				MarkdownDescription: "Initial messages for the thread. Changing the messages forces a new thread to be created.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the message created in the thread.",
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
 						"role": schema.StringAttribute{
 							MarkdownDescription: "The role of the entity creating the message. Must be either \"user\" or \"assistant\".",
 							Required:            true,
@@ -128,11 +141,28 @@ func (r *ThreadResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							MarkdownDescription: "The content of the message.",
 							Required:            true,
 						},
+						"attachment": schema.ListNestedAttribute{
+							MarkdownDescription: "A list of files attached to the message and the tools they should be added to.",
+							Optional:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"file_id": schema.StringAttribute{
+										MarkdownDescription: "The ID of the file to attach.",
+										Required:            true,
+									},
+									"tools": schema.ListAttribute{
+										ElementType:         types.StringType,
+										MarkdownDescription: "A list of tools associated with the attachment.",
+										Optional:            true,
+									},
+								},
+							},
+						},
 						"file_ids": schema.ListAttribute{
 							ElementType:         types.StringType,
 							Optional:            true,
-							DeprecationMessage:  "The file_ids attribute is deprecated in v2 of the Assistants API. Use message attachments instead.",
-							MarkdownDescription: "DEPRECATED: A list of file IDs to attach to the message.",
+							DeprecationMessage:  "The file_ids attribute is deprecated in v2 of the Assistants API. Use attachment instead.",
+							MarkdownDescription: "DEPRECATED: A list of file IDs to attach to the message. Each file is attached for the file_search tool.",
 						},
 						"metadata": schema.MapAttribute{
 							ElementType:         types.StringType,
@@ -205,7 +235,17 @@ func (r *ThreadResource) Create(ctx context.Context, req resource.CreateRequest,
 		threadReq.ToolResources = toolResourcesState
 	}
 
-	tflog.Debug(ctx, "Creating thread")
+	// Initial messages are created together with the thread
+	messages, diags := expandThreadMessages(ctx, plan.Messages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	threadReq.Messages = messages
+
+	tflog.Debug(ctx, "Creating thread", map[string]interface{}{
+		"messages": len(messages),
+	})
 
 	// Create the thread
 	thread, err := r.client.OpenAI.CreateThread(ctx, threadReq)
@@ -246,6 +286,9 @@ func (r *ThreadResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	plan.ToolResources = toolResourcesState
 
+	// Record the IDs of the initial messages so Read can detect their deletion
+	r.setThreadMessageIDs(ctx, thread.ID, plan.Messages)
+
 	// Save into state
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -314,6 +357,37 @@ func (r *ThreadResource) Read(ctx context.Context, req resource.ReadRequest, res
 		state.ToolResources = toolResourcesState
 	}
 
+	// Drop initial messages that were deleted server-side, so the change in
+	// the messages list forces the thread to be recreated. Each message is
+	// fetched by ID, so the cost does not grow with the rest of the thread.
+	remaining := make([]ThreadMessageModel, 0, len(state.Messages))
+	for _, msg := range state.Messages {
+		messageID := msg.ID.ValueString()
+		if messageID == "" {
+			remaining = append(remaining, msg)
+			continue
+		}
+
+		if _, err := r.client.GetMessage(ctx, threadID, messageID); err != nil {
+			if apiErr, ok := err.(*openai.APIError); ok && apiErr.HTTPStatusCode == 404 {
+				tflog.Warn(ctx, "Initial thread message was deleted", map[string]interface{}{
+					"thread_id":  threadID,
+					"message_id": messageID,
+				})
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Error Reading Thread Messages",
+				fmt.Sprintf("Unable to read message %s in thread %s: %s", messageID, threadID, err),
+			)
+			return
+		}
+		remaining = append(remaining, msg)
+	}
+	if state.Messages != nil {
+		state.Messages = remaining
+	}
+
 	// Save into state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -461,6 +535,84 @@ func (r *ThreadResource) ImportState(ctx context.Context, req resource.ImportSta
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+// expandThreadMessages converts the messages block into the initial messages of a thread request
+func expandThreadMessages(ctx context.Context, messages []ThreadMessageModel) ([]openai.ThreadMessage, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var result []openai.ThreadMessage
+
+	for _, msg := range messages {
+		threadMsg := openai.ThreadMessage{
+			Role:        openai.ThreadMessageRole(msg.Role.ValueString()),
+			Content:     msg.Content.ValueString(),
+			Attachments: expandAttachments(msg.Attachments),
+		}
+
+		// file_ids predates attachments; v2 attaches those files for file_search
+		if !msg.FileIDs.IsNull() && !msg.FileIDs.IsUnknown() {
+			var fileIDs []string
+			diags.Append(msg.FileIDs.ElementsAs(ctx, &fileIDs, false)...)
+			if diags.HasError() {
+				return nil, diags
+			}
+			for _, fileID := range fileIDs {
+				threadMsg.Attachments = append(threadMsg.Attachments, openai.ThreadAttachment{
+					FileID: fileID,
+					Tools:  []openai.ThreadAttachmentTool{{Type: string(openai.AssistantToolTypeFileSearch)}},
+				})
+			}
+		}
+		if len(threadMsg.Attachments) == 0 {
+			threadMsg.Attachments = nil
+		}
+
+		if !msg.Metadata.IsNull() && !msg.Metadata.IsUnknown() {
+			metadata := make(map[string]string)
+			diags.Append(msg.Metadata.ElementsAs(ctx, &metadata, false)...)
+			if diags.HasError() {
+				return nil, diags
+			}
+			threadMsg.Metadata = make(map[string]any, len(metadata))
+			for k, v := range metadata {
+				threadMsg.Metadata[k] = v
+			}
+		}
+
+		result = append(result, threadMsg)
+	}
+
+	return result, diags
+}
+
+// setThreadMessageIDs matches the initial messages of a new thread to the
+// messages block by role and content, in order. Messages that cannot be
+// matched keep an empty ID and are not checked for deletion.
+func (r *ThreadResource) setThreadMessageIDs(ctx context.Context, threadID string, messages []ThreadMessageModel) {
+	for i := range messages {
+		messages[i].ID = types.StringValue("")
+	}
+	if len(messages) == 0 {
+		return
+	}
+
+	created, err := r.client.ListMessages(ctx, threadID, client.ListMessagesOptions{})
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to list messages of thread %s: %v", threadID, err))
+		return
+	}
+
+	used := make(map[string]bool, len(created))
+	for i, msg := range messages {
+		for _, c := range created {
+			if used[c.ID] || c.Role != msg.Role.ValueString() || messageText(c) != msg.Content.ValueString() {
+				continue
+			}
+			used[c.ID] = true
+			messages[i].ID = types.StringValue(c.ID)
+			break
+		}
+	}
+}
+
 // Helper function to convert from Terraform tool_resources to OpenAI ToolResources
 func convertTerraformToolResourcesToOpenAI(ctx context.Context, toolResources types.Object) (openai.ToolResources, diag.Diagnostics) {
 	var result openai.ToolResources
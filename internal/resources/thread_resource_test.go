@@ -0,0 +1,125 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testThreadMessage returns an initial thread message as recorded in state
+func testThreadMessage(id string) ThreadMessageModel {
+	return ThreadMessageModel{
+		ID:       types.StringValue(id),
+		Role:     types.StringValue("user"),
+		Content:  types.StringValue("Hello"),
+		FileIDs:  types.ListNull(types.StringType),
+		Metadata: types.MapNull(types.StringType),
+	}
+}
+
+func TestThreadResourceReadDetectsDeletedMessages(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  map[string]int
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "all messages exist",
+			want: []string{"msg_1", "msg_2"},
+		},
+		{
+			name:   "deleted message is dropped",
+			status: map[string]int{"msg_1": http.StatusNotFound},
+			want:   []string{"msg_2"},
+		},
+		{
+			name:    "other errors fail the read",
+			status:  map[string]int{"msg_2": http.StatusInternalServerError},
+			wantErr: "Unable to read message msg_2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if r.URL.Path == "/threads/thread_1" {
+					fmt.Fprint(w, `{"id": "thread_1", "object": "thread", "created_at": 1700000000, "metadata": {}}`)
+					return
+				}
+
+				messageID, ok := strings.CutPrefix(r.URL.Path, "/threads/thread_1/messages/")
+				if !ok {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if status := tt.status[messageID]; status != 0 {
+					w.WriteHeader(status)
+					fmt.Fprint(w, `{"error": {"message": "failed", "type": "invalid_request_error"}}`)
+					return
+				}
+				fmt.Fprintf(w, `{"id": %q, "object": "thread.message", "thread_id": "thread_1", "role": "user", "content": []}`, messageID)
+			}))
+			t.Cleanup(server.Close)
+
+			c, err := client.NewClient(ctx, client.Config{APIKey: "test-key", BaseURL: server.URL})
+			if err != nil {
+				t.Fatalf("creating client: %s", err)
+			}
+
+			var schemaResp resource.SchemaResponse
+			(&ThreadResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			diags := state.SetAttribute(ctx, path.Root("id"), "thread_1")
+			diags.Append(state.SetAttribute(ctx, path.Root("messages"), []ThreadMessageModel{testThreadMessage("msg_1"), testThreadMessage("msg_2")})...)
+			if diags.HasError() {
+				t.Fatalf("encoding state: %v", diags)
+			}
+
+			r := &ThreadResource{client: c}
+			resp := &resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+			if tt.wantErr != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(fmt.Sprint(resp.Diagnostics), tt.wantErr) {
+					t.Fatalf("got diagnostics %v, want an error containing %q", resp.Diagnostics, tt.wantErr)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+
+			var got ThreadResourceModel
+			if diags := resp.State.Get(ctx, &got); diags.HasError() {
+				t.Fatalf("decoding state: %v", diags)
+			}
+			var ids []string
+			for _, msg := range got.Messages {
+				ids = append(ids, msg.ID.ValueString())
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got messages %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
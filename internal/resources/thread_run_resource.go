@@ -0,0 +1,548 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sashabaranov/go-openai"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ThreadRunResource{}
+var _ resource.ResourceWithImportState = &ThreadRunResource{}
+var _ resource.ResourceWithValidateConfig = &ThreadRunResource{}
+
+func NewThreadRunResource() resource.Resource {
+	return &ThreadRunResource{}
+}
+
+// ThreadRunResource defines the resource implementation.
+type ThreadRunResource struct {
+	client *client.Client
+}
+
+// ThreadRunResourceModel describes the resource data model.
+type ThreadRunResourceModel struct {
+	ID                  types.String                `tfsdk:"id"`
+	ThreadID            types.String                `tfsdk:"thread_id"`
+	AssistantID         types.String                `tfsdk:"assistant_id"`
+	Messages            []RunAdditionalMessageModel `tfsdk:"messages"`
+	ThreadMetadata      types.Map                   `tfsdk:"thread_metadata"`
+	ToolResources       types.Object                `tfsdk:"tool_resources"`
+	Model               types.String                `tfsdk:"model"`
+	Instructions        types.String                `tfsdk:"instructions"`
+	Tools               types.List                  `tfsdk:"tools"`
+	Metadata            types.Map                   `tfsdk:"metadata"`
+	MaxPromptTokens     types.Int64                 `tfsdk:"max_prompt_tokens"`
+	MaxCompletionTokens types.Int64                 `tfsdk:"max_completion_tokens"`
+	Temperature         types.Float64               `tfsdk:"temperature"`
+	TopP                types.Float64               `tfsdk:"top_p"`
+	ResponseFormat      types.String                `tfsdk:"response_format"`
+	TruncationStrategy  *RunTruncationStrategyModel `tfsdk:"truncation_strategy"`
+	WaitForCompletion   types.Bool                  `tfsdk:"wait_for_completion"`
+	PollingInterval     types.String                `tfsdk:"polling_interval"`
+	Timeout             types.String                `tfsdk:"timeout"`
+	Status              types.String                `tfsdk:"status"`
+	CreatedAt           types.Int64                 `tfsdk:"created_at"`
+	CompletedAt         types.Int64                 `tfsdk:"completed_at"`
+	LastError           types.String                `tfsdk:"last_error"`
+	IncompleteDetails   types.String                `tfsdk:"incomplete_details"`
+	Usage               types.Object                `tfsdk:"usage"`
+	Steps               types.List                  `tfsdk:"steps"`
+	ResponseContent     types.String                `tfsdk:"response_content"`
+	ResponseMessages    types.List                  `tfsdk:"response_messages"`
+}
+
+func (r *ThreadRunResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "openai_thread_run"
+}
+
+func (r *ThreadRunResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a thread and runs an assistant on it in a single request. Deleting the resource deletes the thread.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the run.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"thread_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the thread created for the run.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"assistant_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the assistant to use for this run.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"thread_metadata": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Set of key-value pairs for the thread's metadata.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"tool_resources": schema.ObjectAttribute{
+				MarkdownDescription: "Resources made available to the thread's tools.",
+				Optional:            true,
+				AttributeTypes: map[string]attr.Type{
+					"code_interpreter": types.ObjectType{
+						AttrTypes: map[string]attr.Type{
+							"file_ids": types.SetType{
+								ElemType: types.StringType,
+							},
+						},
+					},
+					"file_search": types.ObjectType{
+						AttrTypes: map[string]attr.Type{
+							"vector_store_ids": types.SetType{
+								ElemType: types.StringType,
+							},
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
+			"model": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Override the default model used by the assistant.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instructions": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Override the default instructions of the assistant for this run.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tools": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Override the default tools of the assistant for this run.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"metadata": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Set of key-value pairs for run metadata.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_prompt_tokens": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The maximum number of tokens to use for prompts in this run.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"max_completion_tokens": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The maximum number of tokens to generate in this run. If a completion reaches this limit, the run will terminate with a status of 'incomplete'.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"temperature": schema.Float64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Sampling temperature between 0 and 2 for this run. Higher values make the output more random.",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.RequiresReplace(),
+				},
+			},
+			"top_p": schema.Float64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Nucleus sampling probability mass between 0 and 1 for this run. An alternative to temperature.",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.RequiresReplace(),
+				},
+			},
+			"response_format": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The format the model must output for this run. One of `auto`, `text` or `json_object`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to wait for the run to complete before marking the resource as created. Defaults to true.",
+			},
+			"polling_interval": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How often to poll for run status when wait_for_completion is true. Defaults to 5s.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum time to wait for run completion when wait_for_completion is true. Defaults to 10m.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the run (queued, in_progress, completed, incomplete, requires_action, expired, cancelling, cancelled, failed).",
+			},
+			"created_at": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Unix timestamp for when the run was created.",
+			},
+			"completed_at": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Unix timestamp for when the run completed.",
+			},
+			"last_error": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The last error message if the run failed.",
+			},
+			"incomplete_details": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Details about why the run was marked as incomplete, if applicable.",
+			},
+			"usage": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Token usage for the run. Only set once the run has reached a terminal status.",
+				Attributes:          runUsageSchemaAttributes(),
+			},
+			"steps": runStepsSchemaAttribute(),
+			"response_content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The text of every assistant message created by the run, joined by blank lines.",
+			},
+			"response_messages": runResponseMessagesSchemaAttribute(),
+		},
+
+		Blocks: map[string]schema.Block{
+			"messages":            runMessagesSchemaBlock("Messages the thread is created with."),
+			"truncation_strategy": runTruncationStrategySchemaBlock(),
+		},
+	}
+}
+
+func (r *ThreadRunResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ThreadRunResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateRunOptions(data.Temperature, data.TopP, data.ResponseFormat, data.TruncationStrategy)...)
+}
+
+func (r *ThreadRunResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ThreadRunResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ThreadRunResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createReq := &client.CreateThreadAndRunRequest{
+		CreateRunRequest: client.CreateRunRequest{
+			AssistantID:        data.AssistantID.ValueString(),
+			Model:              data.Model.ValueString(),
+			Instructions:       data.Instructions.ValueString(),
+			ResponseFormat:     data.ResponseFormat.ValueString(),
+			TruncationStrategy: expandTruncationStrategy(data.TruncationStrategy),
+		},
+		Thread: openai.ThreadRequest{
+			Messages: expandRunMessages(data.Messages),
+		},
+	}
+
+	if !data.Tools.IsNull() {
+		var toolNames []string
+		resp.Diagnostics.Append(data.Tools.ElementsAs(ctx, &toolNames, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, toolName := range toolNames {
+			createReq.Tools = append(createReq.Tools, openai.AssistantTool{Type: openai.AssistantToolType(toolName)})
+		}
+	}
+
+	if !data.Metadata.IsNull() {
+		metadata := make(map[string]any)
+		resp.Diagnostics.Append(data.Metadata.ElementsAs(ctx, &metadata, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		createReq.Metadata = metadata
+	}
+
+	if !data.ThreadMetadata.IsNull() {
+		metadata := make(map[string]any)
+		resp.Diagnostics.Append(data.ThreadMetadata.ElementsAs(ctx, &metadata, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		createReq.Thread.Metadata = metadata
+	}
+
+	if !data.ToolResources.IsNull() {
+		toolResources, diags := convertTerraformToolResourcesToOpenAIRequest(ctx, data.ToolResources)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		createReq.Thread.ToolResources = toolResources
+	}
+
+	if !data.MaxPromptTokens.IsNull() {
+		createReq.MaxPromptTokens = int(data.MaxPromptTokens.ValueInt64())
+	}
+
+	if !data.MaxCompletionTokens.IsNull() {
+		createReq.MaxCompletionTokens = int(data.MaxCompletionTokens.ValueInt64())
+	}
+
+	if !data.Temperature.IsNull() {
+		temperature := float32(data.Temperature.ValueFloat64())
+		createReq.Temperature = &temperature
+	}
+
+	if !data.TopP.IsNull() {
+		topP := float32(data.TopP.ValueFloat64())
+		createReq.TopP = &topP
+	}
+
+	// Parse the wait settings before creating the run so invalid values fail early
+	waitForCompletion, pollingInterval, timeout, diags := parseRunWaitSettings(data.WaitForCompletion, data.PollingInterval, data.Timeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	startTime := time.Now()
+	run, err := r.client.CreateThreadAndRun(ctx, createReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Thread and Run",
+			fmt.Sprintf("Unable to create thread and run: %s", err),
+		)
+		return
+	}
+
+	// From here on the thread exists, so every failure saves state alongside
+	// the error. Terraform then marks the resource as tainted and deletes the
+	// thread when it is replaced.
+	if waitForCompletion {
+		var aborted bool
+		run, aborted, diags = waitForRun(ctx, r.client, run, pollingInterval, timeout, startTime)
+		resp.Diagnostics.Append(diags...)
+		if aborted {
+			r.abortRun(ctx, &data, run, timeout, resp)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.setRunState(ctx, &data, run)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ThreadRunResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ThreadRunResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	run, err := r.client.GetRun(ctx, data.ID.ValueString(), data.ThreadID.ValueString())
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Run",
+			fmt.Sprintf("Unable to read run: %s", err),
+		)
+		return
+	}
+
+	// An imported run has no status yet, and its messages block is rebuilt
+	// from the thread so a configuration that matches it plans no changes
+	imported := data.Status.IsNull()
+
+	resp.Diagnostics.Append(r.setRunState(ctx, &data, run)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if imported {
+		messages, err := r.client.ListMessages(ctx, run.ThreadID, client.ListMessagesOptions{})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Listing Thread Messages",
+				fmt.Sprintf("Unable to list messages for thread %s: %s", run.ThreadID, err),
+			)
+			return
+		}
+		data.Messages = threadRunMessages(messages, run.CreatedAt)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only changes the wait settings, which have no effect once the run exists
+func (r *ThreadRunResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ThreadRunResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.WaitForCompletion = plan.WaitForCompletion
+	state.PollingInterval = plan.PollingInterval
+	state.Timeout = plan.Timeout
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ThreadRunResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ThreadRunResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A thread with an active run cannot be deleted, so cancel the run first
+	cancelCtx, cancel := context.WithTimeout(ctx, runCancelTimeout)
+	defer cancel()
+	run := &openai.Run{ID: data.ID.ValueString(), ThreadID: data.ThreadID.ValueString()}
+	if _, err := cancelRunAndWait(cancelCtx, r.client, run); err != nil && !strings.Contains(err.Error(), "404") {
+		resp.Diagnostics.AddError(
+			"Error Cancelling Run",
+			fmt.Sprintf("Unable to cancel run: %s", err),
+		)
+		return
+	}
+
+	_, err := r.client.OpenAI.DeleteThread(ctx, data.ThreadID.ValueString())
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		resp.Diagnostics.AddError(
+			"Error Deleting Thread",
+			fmt.Sprintf("Unable to delete thread %s: %s", data.ThreadID.ValueString(), r.client.HandleError(err)),
+		)
+		return
+	}
+}
+
+func (r *ThreadRunResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Expected format: thread_id:run_id
+	idParts := strings.Split(req.ID, ":")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Error Importing Thread Run",
+			"Invalid import ID format. Expected format: thread_id:run_id",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("thread_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+}
+
+// abortRun cancels a run that was interrupted or timed out while Create was
+// waiting for it and saves it to state as tainted
+func (r *ThreadRunResource) abortRun(ctx context.Context, data *ThreadRunResourceModel, run *openai.Run, timeout time.Duration, resp *resource.CreateResponse) {
+	abortRun(ctx, r.client, run, timeout, "thread", resp, func(ctx context.Context, final *openai.Run) diag.Diagnostics {
+		diags := r.setRunState(ctx, data, final)
+		diags.Append(resp.State.Set(ctx, data)...)
+		return diags
+	})
+}
+
+// setRunState records everything the API reports about a run in the model
+func (r *ThreadRunResource) setRunState(ctx context.Context, data *ThreadRunResourceModel, run *openai.Run) diag.Diagnostics {
+	state, diags := flattenRunState(ctx, r.client, run)
+	data.ID = state.ID
+	data.ThreadID = state.ThreadID
+	data.AssistantID = state.AssistantID
+	data.Status = state.Status
+	data.CreatedAt = state.CreatedAt
+	data.CompletedAt = state.CompletedAt
+	data.LastError = state.LastError
+	data.IncompleteDetails = state.IncompleteDetails
+	data.Usage = state.Usage
+	data.Steps = state.Steps
+	data.ResponseContent = state.ResponseContent
+	data.ResponseMessages = state.ResponseMessages
+	return diags
+}
+
+// threadRunMessages rebuilds the messages block from the messages a thread
+// was created with, which are those listed before the run that no run created
+func threadRunMessages(messages []client.Message, runCreatedAt int64) []RunAdditionalMessageModel {
+	var result []RunAdditionalMessageModel
+	for _, msg := range messages {
+		if (msg.RunID != nil && *msg.RunID != "") || msg.CreatedAt > runCreatedAt {
+			continue
+		}
+
+		model := RunAdditionalMessageModel{
+			Role:    types.StringValue(msg.Role),
+			Content: types.StringValue(messageText(msg)),
+		}
+		for _, attachment := range msg.Attachments {
+			a := AttachmentModel{FileID: types.StringValue(attachment.FileID)}
+			for _, tool := range attachment.Tools {
+				a.Tools = append(a.Tools, types.StringValue(tool.Type))
+			}
+			model.Attachments = append(model.Attachments, a)
+		}
+		if len(msg.Metadata) > 0 {
+			model.Metadata = make(map[string]string, len(msg.Metadata))
+			for k, v := range msg.Metadata {
+				model.Metadata[k] = fmt.Sprint(v)
+			}
+		}
+		result = append(result, model)
+	}
+	return result
+}
@@ -0,0 +1,393 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// threadTranscript holds everything needed to render a thread to a file
+type threadTranscript struct {
+	threadID  string
+	messages  []client.Message
+	runs      []openai.Run
+	steps     map[string][]client.RunStep
+	fileNames map[string]string
+}
+
+// transcriptCitation is an annotation resolved to the name of the file it refers to
+type transcriptCitation struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	FileID   string `json:"file_id"`
+	Filename string `json:"filename"`
+}
+
+// transcriptAttachment is a message attachment resolved to its file name
+type transcriptAttachment struct {
+	FileID   string   `json:"file_id"`
+	Filename string   `json:"filename"`
+	Tools    []string `json:"tools,omitempty"`
+}
+
+// transcriptMessageRecord is a message line of a JSONL transcript
+type transcriptMessageRecord struct {
+	Type        string                 `json:"type"`
+	ID          string                 `json:"id"`
+	CreatedAt   int64                  `json:"created_at"`
+	Role        string                 `json:"role"`
+	RunID       string                 `json:"run_id,omitempty"`
+	AssistantID string                 `json:"assistant_id,omitempty"`
+	Text        string                 `json:"text"`
+	Citations   []transcriptCitation   `json:"citations,omitempty"`
+	Attachments []transcriptAttachment `json:"attachments,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// transcriptRunRecord is a run line of a JSONL transcript
+type transcriptRunRecord struct {
+	Type        string `json:"type"`
+	ID          string `json:"id"`
+	CreatedAt   int64  `json:"created_at"`
+	AssistantID string `json:"assistant_id"`
+	Status      string `json:"status"`
+	LastError   string `json:"last_error,omitempty"`
+}
+
+// transcriptStepRecord is a run step line of a JSONL transcript
+type transcriptStepRecord struct {
+	Type        string                   `json:"type"`
+	ID          string                   `json:"id"`
+	RunID       string                   `json:"run_id"`
+	CreatedAt   int64                    `json:"created_at"`
+	CompletedAt *int64                   `json:"completed_at,omitempty"`
+	StepType    string                   `json:"step_type"`
+	Status      string                   `json:"status"`
+	MessageID   string                   `json:"message_id,omitempty"`
+	ToolCalls   []client.RunStepToolCall `json:"tool_calls,omitempty"`
+	LastError   string                   `json:"last_error,omitempty"`
+	Usage       *client.RunUsage         `json:"usage,omitempty"`
+}
+
+// loadThreadTranscript fetches every message of a thread, its runs and their
+// steps, and the names of the files the messages reference
+func loadThreadTranscript(ctx context.Context, c *client.Client, threadID string, includeRunSteps bool) (*threadTranscript, error) {
+	messages, err := c.ListMessages(ctx, threadID, client.ListMessagesOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	t := &threadTranscript{
+		threadID:  threadID,
+		messages:  messages,
+		steps:     make(map[string][]client.RunStep),
+		fileNames: make(map[string]string),
+	}
+
+	// Runs are listed from the thread rather than from the messages, so
+	// failed, cancelled and tool-only runs that created no message are kept
+	if includeRunSteps {
+		runs, err := c.ListRuns(ctx, threadID)
+		if err != nil {
+			return nil, err
+		}
+		t.runs = runs
+		for _, run := range runs {
+			steps, err := c.ListRunSteps(ctx, threadID, run.ID)
+			if err != nil {
+				return nil, err
+			}
+			t.steps[run.ID] = steps
+		}
+	}
+
+	// Files that have since been deleted keep their ID as their name
+	for _, msg := range messages {
+		var fileIDs []string
+		for _, attachment := range msg.Attachments {
+			fileIDs = append(fileIDs, attachment.FileID)
+		}
+		for _, content := range msg.Content {
+			if content.Text == nil {
+				continue
+			}
+			for _, annotation := range content.Text.Annotations {
+				fileIDs = append(fileIDs, annotation.FileID())
+			}
+		}
+
+		for _, fileID := range fileIDs {
+			if _, ok := t.fileNames[fileID]; ok || fileID == "" {
+				continue
+			}
+			t.fileNames[fileID] = fileID
+			if file, err := c.OpenAI.GetFile(ctx, fileID); err == nil && file.FileName != "" {
+				t.fileNames[fileID] = path.Base(file.FileName)
+			}
+		}
+	}
+
+	return t, nil
+}
+
+// threadSignature returns the ID of the latest message of a thread and the
+// number of messages it holds, which change whenever a message is added or
+// removed
+func threadSignature(ctx context.Context, c *client.Client, threadID string) (string, int, error) {
+	messages, err := c.ListMessages(ctx, threadID, client.ListMessagesOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+	if len(messages) == 0 {
+		return "", 0, nil
+	}
+	return messages[len(messages)-1].ID, len(messages), nil
+}
+
+// fileName returns the resolved name of a file
+func (t *threadTranscript) fileName(fileID string) string {
+	if name, ok := t.fileNames[fileID]; ok {
+		return name
+	}
+	return fileID
+}
+
+// resolveText returns the text of a message with citation markers replaced by
+// numbered references and sandbox file paths replaced by file names
+func (t *threadTranscript) resolveText(msg client.Message) (string, []transcriptCitation) {
+	var parts []string
+	var citations []transcriptCitation
+
+	for _, content := range msg.Content {
+		if content.Type != "text" || content.Text == nil {
+			continue
+		}
+		text := content.Text.Value
+		for _, annotation := range content.Text.Annotations {
+			citation := transcriptCitation{
+				Type:     annotation.Type,
+				Text:     annotation.Text,
+				FileID:   annotation.FileID(),
+				Filename: t.fileName(annotation.FileID()),
+			}
+			replacement := citation.Filename
+			if annotation.FilePath == nil {
+				citations = append(citations, citation)
+				replacement = fmt.Sprintf("[%d]", len(citations))
+			}
+			if annotation.Text != "" {
+				text = strings.Replace(text, annotation.Text, replacement, 1)
+			}
+		}
+		parts = append(parts, text)
+	}
+
+	return strings.Join(parts, "\n\n"), citations
+}
+
+// attachments returns the attachments of a message resolved to file names
+func (t *threadTranscript) attachments(msg client.Message) []transcriptAttachment {
+	var result []transcriptAttachment
+	for _, attachment := range msg.Attachments {
+		a := transcriptAttachment{
+			FileID:   attachment.FileID,
+			Filename: t.fileName(attachment.FileID),
+		}
+		for _, tool := range attachment.Tools {
+			a.Tools = append(a.Tools, tool.Type)
+		}
+		result = append(result, a)
+	}
+	return result
+}
+
+// forEachEntry visits the messages and runs merged by creation time. A run is
+// visited before the first message it created even when both were created in
+// the same second, and runs created after the last message come last.
+func (t *threadTranscript) forEachEntry(onRun func(run openai.Run, steps []client.RunStep), onMessage func(msg client.Message)) {
+	next := 0
+	for _, msg := range t.messages {
+		end := next
+		for end < len(t.runs) && t.runs[end].CreatedAt < msg.CreatedAt {
+			end++
+		}
+		if msg.RunID != nil && *msg.RunID != "" {
+			for i := end; i < len(t.runs); i++ {
+				if t.runs[i].ID == *msg.RunID {
+					end = i + 1
+					break
+				}
+			}
+		}
+		for ; next < end; next++ {
+			onRun(t.runs[next], t.steps[t.runs[next].ID])
+		}
+		onMessage(msg)
+	}
+	for ; next < len(t.runs); next++ {
+		onRun(t.runs[next], t.steps[t.runs[next].ID])
+	}
+}
+
+// formatTimestamp renders a Unix timestamp in UTC
+func formatTimestamp(ts int64) string {
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
+
+// renderMarkdown renders the transcript as a Markdown document
+func (t *threadTranscript) renderMarkdown() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# Thread %s\n", t.threadID)
+
+	t.forEachEntry(func(run openai.Run, steps []client.RunStep) {
+		fmt.Fprintf(&b, "\n---\n\n**Run %s** (%s)", run.ID, run.Status)
+		if run.LastError != nil {
+			fmt.Fprintf(&b, ": %s", run.LastError.Message)
+		}
+		b.WriteString("\n")
+		if len(steps) > 0 {
+			b.WriteString("\n")
+		}
+		for _, step := range steps {
+			fmt.Fprintf(&b, "- `%s` %s (%s)", step.ID, step.Type, step.Status)
+			if step.LastError != nil {
+				fmt.Fprintf(&b, ": %s", step.LastError.Message)
+			}
+			b.WriteString("\n")
+			for _, call := range step.StepDetails.ToolCalls {
+				fmt.Fprintf(&b, "  - %s", call.Type)
+				switch {
+				case call.Function != nil:
+					fmt.Fprintf(&b, " `%s(%s)`", call.Function.Name, call.Function.Arguments)
+				case call.FileSearch != nil:
+					var names []string
+					for _, result := range call.FileSearch.Results {
+						names = append(names, result.FileName)
+					}
+					if len(names) > 0 {
+						fmt.Fprintf(&b, ": %s", strings.Join(names, ", "))
+					}
+				}
+				b.WriteString("\n")
+				if call.CodeInterpreter != nil && call.CodeInterpreter.Input != "" {
+					fmt.Fprintf(&b, "\n    ```python\n%s\n    ```\n\n", indent(call.CodeInterpreter.Input, "    "))
+				}
+			}
+		}
+	}, func(msg client.Message) {
+		text, citations := t.resolveText(msg)
+		fmt.Fprintf(&b, "\n## %s · %s\n\n", msg.Role, formatTimestamp(msg.CreatedAt))
+		fmt.Fprintf(&b, "<!-- message %s -->\n\n", msg.ID)
+		if text != "" {
+			b.WriteString(text)
+			b.WriteString("\n")
+		}
+		for _, content := range msg.Content {
+			switch {
+			case content.ImageFile != nil:
+				fmt.Fprintf(&b, "\n![image](%s)\n", t.fileName(content.ImageFile.FileID))
+			case content.ImageURL != nil:
+				fmt.Fprintf(&b, "\n![image](%s)\n", content.ImageURL.URL)
+			}
+		}
+		if attachments := t.attachments(msg); len(attachments) > 0 {
+			b.WriteString("\nAttachments:\n\n")
+			for _, a := range attachments {
+				fmt.Fprintf(&b, "- %s (`%s`)\n", a.Filename, a.FileID)
+			}
+		}
+		if len(citations) > 0 {
+			b.WriteString("\nSources:\n\n")
+			for i, c := range citations {
+				fmt.Fprintf(&b, "%d. %s (`%s`)\n", i+1, c.Filename, c.FileID)
+			}
+		}
+	})
+
+	return b.Bytes()
+}
+
+// renderJSONL renders the transcript as one JSON object per line
+func (t *threadTranscript) renderJSONL() ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	var err error
+
+	write := func(v interface{}) {
+		if err == nil {
+			err = enc.Encode(v)
+		}
+	}
+
+	t.forEachEntry(func(run openai.Run, steps []client.RunStep) {
+		runRecord := transcriptRunRecord{
+			Type:        "run",
+			ID:          run.ID,
+			CreatedAt:   run.CreatedAt,
+			AssistantID: run.AssistantID,
+			Status:      string(run.Status),
+		}
+		if run.LastError != nil {
+			runRecord.LastError = run.LastError.Message
+		}
+		write(runRecord)
+
+		for _, step := range steps {
+			record := transcriptStepRecord{
+				Type:        "run_step",
+				ID:          step.ID,
+				RunID:       run.ID,
+				CreatedAt:   step.CreatedAt,
+				CompletedAt: step.CompletedAt,
+				StepType:    step.Type,
+				Status:      step.Status,
+				ToolCalls:   step.StepDetails.ToolCalls,
+				Usage:       step.Usage,
+			}
+			if step.StepDetails.MessageCreation != nil {
+				record.MessageID = step.StepDetails.MessageCreation.MessageID
+			}
+			if step.LastError != nil {
+				record.LastError = step.LastError.Message
+			}
+			write(record)
+		}
+	}, func(msg client.Message) {
+		text, citations := t.resolveText(msg)
+		record := transcriptMessageRecord{
+			Type:        "message",
+			ID:          msg.ID,
+			CreatedAt:   msg.CreatedAt,
+			Role:        msg.Role,
+			Text:        text,
+			Citations:   citations,
+			Attachments: t.attachments(msg),
+			Metadata:    msg.Metadata,
+		}
+		if msg.RunID != nil {
+			record.RunID = *msg.RunID
+		}
+		if msg.AssistantID != nil {
+			record.AssistantID = *msg.AssistantID
+		}
+		write(record)
+	})
+
+	return b.Bytes(), err
+}
+
+// indent prefixes every line of s
+func indent(s string, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
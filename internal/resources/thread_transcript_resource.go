@@ -0,0 +1,349 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/darnold/terraform-provider-openai/internal/fsutil"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sashabaranov/go-openai"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &ThreadTranscriptResource{}
+var _ resource.ResourceWithValidateConfig = &ThreadTranscriptResource{}
+var _ resource.ResourceWithModifyPlan = &ThreadTranscriptResource{}
+
+const (
+	transcriptFormatMarkdown = "markdown"
+	transcriptFormatJSONL    = "jsonl"
+)
+
+func NewThreadTranscriptResource() resource.Resource {
+	return &ThreadTranscriptResource{}
+}
+
+// ThreadTranscriptResource defines the resource implementation.
+type ThreadTranscriptResource struct {
+	client *client.Client
+}
+
+// ThreadTranscriptResourceModel describes the resource data model.
+type ThreadTranscriptResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	ThreadID        types.String `tfsdk:"thread_id"`
+	Format          types.String `tfsdk:"format"`
+	OutputPath      types.String `tfsdk:"output_path"`
+	IncludeRunSteps types.Bool   `tfsdk:"include_run_steps"`
+	MessageCount    types.Int64  `tfsdk:"message_count"`
+	LatestMessageID types.String `tfsdk:"latest_message_id"`
+	Bytes           types.Int64  `tfsdk:"bytes"`
+	SHA256          types.String `tfsdk:"sha256"`
+}
+
+// Schema returns the schema for this resource.
+func (r *ThreadTranscriptResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders every message and run step of an OpenAI thread to a local Markdown or JSONL file. The file is re-rendered only when messages are added to or removed from the thread.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the thread.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"thread_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the thread to render.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"format": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The transcript format, either `markdown` or `jsonl`. Defaults to `markdown`.",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The local path the transcript is written to. Missing parent directories are created.",
+			},
+			"include_run_steps": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to include the thread's runs and their steps. Defaults to true.",
+			},
+			"message_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of messages in the transcript.",
+			},
+			"latest_message_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the most recent message in the transcript.",
+			},
+			"bytes": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The size of the transcript in bytes.",
+			},
+			"sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The hex-encoded SHA-256 checksum of the transcript.",
+			},
+		},
+	}
+}
+
+func (r *ThreadTranscriptResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_thread_transcript"
+}
+
+func (r *ThreadTranscriptResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ThreadTranscriptResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ThreadTranscriptResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Format.IsNull() && !data.Format.IsUnknown() {
+		switch data.Format.ValueString() {
+		case transcriptFormatMarkdown, transcriptFormatJSONL:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("format"),
+				"Invalid Format",
+				fmt.Sprintf("format must be either %q or %q, got %q", transcriptFormatMarkdown, transcriptFormatJSONL, data.Format.ValueString()),
+			)
+		}
+	}
+
+	if !data.OutputPath.IsNull() && !data.OutputPath.IsUnknown() && data.OutputPath.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("output_path"),
+			"Invalid Output Path",
+			"output_path must not be empty",
+		)
+	}
+}
+
+// ModifyPlan plans a re-render when messages were added to or removed from
+// the thread, or the file on disk no longer matches the checksum in state.
+// Only the thread's messages are listed, so runs, run steps and file names
+// are not fetched on every plan.
+func (r *ThreadTranscriptResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan, state ThreadTranscriptResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Changed arguments already re-render the transcript
+	if plan.ThreadID.IsUnknown() || plan.Format.IsUnknown() || plan.OutputPath.IsUnknown() || plan.IncludeRunSteps.IsUnknown() ||
+		!plan.ThreadID.Equal(state.ThreadID) || !plan.Format.Equal(state.Format) ||
+		!plan.OutputPath.Equal(state.OutputPath) || !plan.IncludeRunSteps.Equal(state.IncludeRunSteps) {
+		return
+	}
+
+	latestMessageID, messageCount, err := threadSignature(ctx, r.client, plan.ThreadID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Thread",
+			fmt.Sprintf("Unable to list messages of thread %s: %s", plan.ThreadID.ValueString(), r.client.HandleError(err)),
+		)
+		return
+	}
+
+	onDisk, err := os.ReadFile(plan.OutputPath.ValueString())
+	if latestMessageID == state.LatestMessageID.ValueString() && int64(messageCount) == state.MessageCount.ValueInt64() &&
+		err == nil && fsutil.SHA256Hex(onDisk) == state.SHA256.ValueString() {
+		return
+	}
+
+	tflog.Debug(ctx, "Thread transcript is out of date", map[string]interface{}{
+		"thread_id":   plan.ThreadID.ValueString(),
+		"output_path": plan.OutputPath.ValueString(),
+	})
+
+	resp.Plan.SetAttribute(ctx, path.Root("message_count"), types.Int64Unknown())
+	resp.Plan.SetAttribute(ctx, path.Root("latest_message_id"), types.StringUnknown())
+	resp.Plan.SetAttribute(ctx, path.Root("bytes"), types.Int64Unknown())
+	resp.Plan.SetAttribute(ctx, path.Root("sha256"), types.StringUnknown())
+}
+
+func (r *ThreadTranscriptResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ThreadTranscriptResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.writeTranscript(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Writing Thread Transcript",
+			fmt.Sprintf("Unable to write transcript of thread %s: %s", plan.ThreadID.ValueString(), r.client.HandleError(err)),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *ThreadTranscriptResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ThreadTranscriptResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.OpenAI.RetrieveThread(ctx, state.ThreadID.ValueString())
+	if err != nil {
+		if apiErr, ok := err.(*openai.APIError); ok && apiErr.HTTPStatusCode == 404 {
+			// Thread doesn't exist anymore, remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Thread",
+			fmt.Sprintf("Unable to read thread details: %s", r.client.HandleError(err)),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ThreadTranscriptResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ThreadTranscriptResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.writeTranscript(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Writing Thread Transcript",
+			fmt.Sprintf("Unable to write transcript of thread %s: %s", plan.ThreadID.ValueString(), r.client.HandleError(err)),
+		)
+		return
+	}
+
+	// Remove the transcript from its previous location
+	if oldPath := state.OutputPath.ValueString(); oldPath != "" && filepath.Clean(oldPath) != filepath.Clean(plan.OutputPath.ValueString()) {
+		if err := os.Remove(oldPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			resp.Diagnostics.AddWarning(
+				"Error Removing Previous Transcript",
+				fmt.Sprintf("Unable to remove %s: %s", oldPath, err),
+			)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *ThreadTranscriptResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ThreadTranscriptResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := state.OutputPath.ValueString()
+	if outputPath == "" {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting thread transcript", map[string]interface{}{
+		"output_path": outputPath,
+	})
+
+	if err := os.Remove(outputPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Thread Transcript",
+			fmt.Sprintf("Unable to remove %s: %s", outputPath, err),
+		)
+	}
+}
+
+// renderTranscript renders the transcript configured in data and returns it
+// along with the messages it contains
+func (r *ThreadTranscriptResource) renderTranscript(ctx context.Context, data *ThreadTranscriptResourceModel) ([]byte, []client.Message, error) {
+	includeRunSteps := true
+	if !data.IncludeRunSteps.IsNull() {
+		includeRunSteps = data.IncludeRunSteps.ValueBool()
+	}
+
+	transcript, err := loadThreadTranscript(ctx, r.client, data.ThreadID.ValueString(), includeRunSteps)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if data.Format.ValueString() == transcriptFormatJSONL {
+		content, err := transcript.renderJSONL()
+		return content, transcript.messages, err
+	}
+	return transcript.renderMarkdown(), transcript.messages, nil
+}
+
+// writeTranscript renders the transcript, replaces the output file with it and
+// records the computed attributes in data
+func (r *ThreadTranscriptResource) writeTranscript(ctx context.Context, data *ThreadTranscriptResourceModel) error {
+	content, messages, err := r.renderTranscript(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so the transcript is never left half written
+	outputPath := data.OutputPath.ValueString()
+	if err := fsutil.WriteFileAtomic(outputPath, content); err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, "Wrote thread transcript", map[string]interface{}{
+		"thread_id":     data.ThreadID.ValueString(),
+		"output_path":   outputPath,
+		"message_count": len(messages),
+	})
+
+	data.ID = data.ThreadID
+	data.MessageCount = types.Int64Value(int64(len(messages)))
+	data.LatestMessageID = types.StringValue("")
+	if len(messages) > 0 {
+		data.LatestMessageID = types.StringValue(messages[len(messages)-1].ID)
+	}
+	data.Bytes = types.Int64Value(int64(len(content)))
+	data.SHA256 = types.StringValue(fsutil.SHA256Hex(content))
+	return nil
+}
@@ -0,0 +1,275 @@
+package resources
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// testTranscript returns a transcript of a thread where a user attaches a
+// file and a run answers with a file search and a code interpreter call. With
+// steps, it also holds a later run that failed without creating a message.
+func testTranscript(t *testing.T, withSteps bool) *threadTranscript {
+	t.Helper()
+
+	messages := decodeMessages(t, `[
+		{
+			"id": "msg_1", "role": "user", "created_at": 1700000000,
+			"content": [{"type": "text", "text": {"value": "Summarize the report", "annotations": []}}],
+			"attachments": [{"file_id": "file-report", "tools": [{"type": "file_search"}]}],
+			"metadata": {"source": "test"}
+		},
+		{
+			"id": "msg_2", "role": "assistant", "created_at": 1700000060, "run_id": "run_1", "assistant_id": "asst_1",
+			"content": [
+				{"type": "text", "text": {"value": "Sales grew【4:0†source】. See sandbox:/mnt/data/chart.png", "annotations": [
+					{"type": "file_citation", "text": "【4:0†source】", "file_citation": {"file_id": "file-report"}},
+					{"type": "file_path", "text": "sandbox:/mnt/data/chart.png", "file_path": {"file_id": "file-chart"}}
+				]}},
+				{"type": "image_file", "image_file": {"file_id": "file-chart"}}
+			]
+		}
+	]`)
+
+	transcript := &threadTranscript{
+		threadID: "thread_1",
+		messages: messages,
+		steps:    make(map[string][]client.RunStep),
+		fileNames: map[string]string{
+			"file-report": "report.pdf",
+			"file-chart":  "chart.png",
+		},
+	}
+
+	if withSteps {
+		err := json.Unmarshal([]byte(`[
+			{"id": "run_1", "thread_id": "thread_1", "assistant_id": "asst_1", "created_at": 1700000020, "status": "completed"},
+			{"id": "run_2", "thread_id": "thread_1", "assistant_id": "asst_1", "created_at": 1700000100, "status": "failed",
+				"last_error": {"code": "rate_limit_exceeded", "message": "Rate limit reached"}}
+		]`), &transcript.runs)
+		if err != nil {
+			t.Fatalf("decoding runs: %s", err)
+		}
+
+		var steps []client.RunStep
+		err = json.Unmarshal([]byte(`[
+			{
+				"id": "step_1", "run_id": "run_1", "created_at": 1700000030, "completed_at": 1700000040,
+				"type": "tool_calls", "status": "completed",
+				"step_details": {"type": "tool_calls", "tool_calls": [
+					{"id": "call_1", "type": "file_search", "file_search": {"results": [{"file_id": "file-report", "file_name": "report.pdf", "score": 0.9}]}},
+					{"id": "call_2", "type": "code_interpreter", "code_interpreter": {"input": "plot()\nsave()", "outputs": []}}
+				]}
+			},
+			{
+				"id": "step_2", "run_id": "run_1", "created_at": 1700000050,
+				"type": "message_creation", "status": "completed",
+				"step_details": {"type": "message_creation", "message_creation": {"message_id": "msg_2"}}
+			}
+		]`), &steps)
+		if err != nil {
+			t.Fatalf("decoding run steps: %s", err)
+		}
+		transcript.steps["run_1"] = steps
+	}
+
+	return transcript
+}
+
+func TestThreadTranscriptRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name      string
+		withSteps bool
+		want      string
+	}{
+		{
+			name: "messages",
+			want: `# Thread thread_1
+
+## user · 2023-11-14T22:13:20Z
+
+<!-- message msg_1 -->
+
+Summarize the report
+
+Attachments:
+
+- report.pdf (` + "`file-report`" + `)
+
+## assistant · 2023-11-14T22:14:20Z
+
+<!-- message msg_2 -->
+
+Sales grew[1]. See chart.png
+
+![image](chart.png)
+
+Sources:
+
+1. report.pdf (` + "`file-report`" + `)
+`,
+		},
+		{
+			name:      "messages and run steps",
+			withSteps: true,
+			want: `# Thread thread_1
+
+## user · 2023-11-14T22:13:20Z
+
+<!-- message msg_1 -->
+
+Summarize the report
+
+Attachments:
+
+- report.pdf (` + "`file-report`" + `)
+
+---
+
+**Run run_1** (completed)
+
+- ` + "`step_1`" + ` tool_calls (completed)
+  - file_search: report.pdf
+  - code_interpreter
+
+    ` + "```python" + `
+    plot()
+    save()
+    ` + "```" + `
+
+- ` + "`step_2`" + ` message_creation (completed)
+
+## assistant · 2023-11-14T22:14:20Z
+
+<!-- message msg_2 -->
+
+Sales grew[1]. See chart.png
+
+![image](chart.png)
+
+Sources:
+
+1. report.pdf (` + "`file-report`" + `)
+
+---
+
+**Run run_2** (failed): Rate limit reached
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(testTranscript(t, tt.withSteps).renderMarkdown())
+			if got != tt.want {
+				t.Fatalf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThreadTranscriptRenderJSONL(t *testing.T) {
+	tests := []struct {
+		name      string
+		withSteps bool
+		want      string
+	}{
+		{
+			name: "messages",
+			want: `{"type":"message","id":"msg_1","created_at":1700000000,"role":"user","text":"Summarize the report","attachments":[{"file_id":"file-report","filename":"report.pdf","tools":["file_search"]}],"metadata":{"source":"test"}}
+{"type":"message","id":"msg_2","created_at":1700000060,"role":"assistant","run_id":"run_1","assistant_id":"asst_1","text":"Sales grew[1]. See chart.png","citations":[{"type":"file_citation","text":"【4:0†source】","file_id":"file-report","filename":"report.pdf"}]}
+`,
+		},
+		{
+			name:      "messages and run steps",
+			withSteps: true,
+			want: `{"type":"message","id":"msg_1","created_at":1700000000,"role":"user","text":"Summarize the report","attachments":[{"file_id":"file-report","filename":"report.pdf","tools":["file_search"]}],"metadata":{"source":"test"}}
+{"type":"run","id":"run_1","created_at":1700000020,"assistant_id":"asst_1","status":"completed"}
+{"type":"run_step","id":"step_1","run_id":"run_1","created_at":1700000030,"completed_at":1700000040,"step_type":"tool_calls","status":"completed","tool_calls":[{"id":"call_1","type":"file_search","file_search":{"results":[{"file_id":"file-report","file_name":"report.pdf","score":0.9}]}},{"id":"call_2","type":"code_interpreter","code_interpreter":{"input":"plot()\nsave()","outputs":[]}}]}
+{"type":"run_step","id":"step_2","run_id":"run_1","created_at":1700000050,"step_type":"message_creation","status":"completed","message_id":"msg_2"}
+{"type":"message","id":"msg_2","created_at":1700000060,"role":"assistant","run_id":"run_1","assistant_id":"asst_1","text":"Sales grew[1]. See chart.png","citations":[{"type":"file_citation","text":"【4:0†source】","file_id":"file-report","filename":"report.pdf"}]}
+{"type":"run","id":"run_2","created_at":1700000100,"assistant_id":"asst_1","status":"failed","last_error":"Rate limit reached"}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testTranscript(t, tt.withSteps).renderJSONL()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThreadTranscriptEntryOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages string
+		runs     string
+		want     []string
+	}{
+		{
+			name: "runs without messages between messages",
+			messages: `[
+				{"id": "msg_1", "created_at": 100},
+				{"id": "msg_2", "created_at": 300, "run_id": "run_2"}
+			]`,
+			runs: `[
+				{"id": "run_1", "created_at": 150, "status": "cancelled"},
+				{"id": "run_2", "created_at": 200, "status": "completed"}
+			]`,
+			want: []string{"msg_1", "run_1", "run_2", "msg_2"},
+		},
+		{
+			name: "run created in the same second as its message",
+			messages: `[
+				{"id": "msg_1", "created_at": 100},
+				{"id": "msg_2", "created_at": 100, "run_id": "run_1"}
+			]`,
+			runs: `[{"id": "run_1", "created_at": 100, "status": "completed"}]`,
+			want: []string{"msg_1", "run_1", "msg_2"},
+		},
+		{
+			name:     "runs after the last message",
+			messages: `[{"id": "msg_1", "created_at": 100}]`,
+			runs: `[
+				{"id": "run_1", "created_at": 100, "status": "failed"},
+				{"id": "run_2", "created_at": 200, "status": "requires_action"}
+			]`,
+			want: []string{"msg_1", "run_1", "run_2"},
+		},
+		{
+			name:     "no runs",
+			messages: `[{"id": "msg_1", "created_at": 100}, {"id": "msg_2", "created_at": 200}]`,
+			runs:     `[]`,
+			want:     []string{"msg_1", "msg_2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcript := &threadTranscript{messages: decodeMessages(t, tt.messages)}
+			if err := json.Unmarshal([]byte(tt.runs), &transcript.runs); err != nil {
+				t.Fatalf("decoding runs: %s", err)
+			}
+
+			var got []string
+			transcript.forEachEntry(func(run openai.Run, _ []client.RunStep) {
+				got = append(got, run.ID)
+			}, func(msg client.Message) {
+				got = append(got, msg.ID)
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package resources
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	// maxVectorStoreFileAttributes is the largest number of attributes a file may have
+	maxVectorStoreFileAttributes = 16
+	// maxVectorStoreFileAttributeKeyLength is the longest attribute key the API accepts
+	maxVectorStoreFileAttributeKeyLength = 64
+	// maxVectorStoreFileAttributeValueLength is the longest string value the API accepts
+	maxVectorStoreFileAttributeValueLength = 512
+)
+
+// validateVectorStoreFileAttributes checks that attributes is an object or
+// map of at most 16 string, number or bool values. Unknown values are skipped
+// so they can be checked once they are known.
+func validateVectorStoreFileAttributes(attributes types.Dynamic, attrPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if attributes.IsNull() || attributes.IsUnknown() || attributes.IsUnderlyingValueUnknown() {
+		return diags
+	}
+
+	elements, ok := vectorStoreFileAttributeElements(attributes)
+	if !ok {
+		diags.AddAttributeError(
+			attrPath,
+			"Invalid Attributes",
+			"attributes must be an object or map of string, number or bool values, such as { tenant = \"acme\", year = 2024 }",
+		)
+		return diags
+	}
+
+	if len(elements) > maxVectorStoreFileAttributes {
+		diags.AddAttributeError(
+			attrPath,
+			"Invalid Attributes",
+			fmt.Sprintf("attributes may hold at most %d values, got %d", maxVectorStoreFileAttributes, len(elements)),
+		)
+	}
+
+	for _, key := range sortedAttributeKeys(elements) {
+		if len(key) > maxVectorStoreFileAttributeKeyLength {
+			diags.AddAttributeError(
+				attrPath,
+				"Invalid Attributes",
+				fmt.Sprintf("attribute key %q is longer than %d characters", key, maxVectorStoreFileAttributeKeyLength),
+			)
+		}
+
+		switch v := elements[key].(type) {
+		case basetypes.StringValue:
+			if !v.IsUnknown() && len(v.ValueString()) > maxVectorStoreFileAttributeValueLength {
+				diags.AddAttributeError(
+					attrPath,
+					"Invalid Attributes",
+					fmt.Sprintf("attribute %q is longer than %d characters", key, maxVectorStoreFileAttributeValueLength),
+				)
+			}
+			if v.IsNull() {
+				diags.AddAttributeError(attrPath, "Invalid Attributes", fmt.Sprintf("attribute %q must not be null", key))
+			}
+		case basetypes.NumberValue, basetypes.BoolValue:
+			if v.IsNull() {
+				diags.AddAttributeError(attrPath, "Invalid Attributes", fmt.Sprintf("attribute %q must not be null", key))
+			}
+		default:
+			diags.AddAttributeError(
+				attrPath,
+				"Invalid Attributes",
+				fmt.Sprintf("attribute %q must be a string, number or bool, got %s", key, elements[key].Type(nil)),
+			)
+		}
+	}
+
+	return diags
+}
+
+// expandVectorStoreFileAttributes converts configured attributes into their
+// API form. Null attributes become nil.
+func expandVectorStoreFileAttributes(attributes types.Dynamic) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if attributes.IsNull() {
+		return nil, diags
+	}
+
+	elements, ok := vectorStoreFileAttributeElements(attributes)
+	if !ok {
+		diags.AddError(
+			"Invalid Attributes",
+			"attributes must be an object or map of string, number or bool values",
+		)
+		return nil, diags
+	}
+
+	result := make(map[string]interface{}, len(elements))
+	for key, value := range elements {
+		switch v := value.(type) {
+		case basetypes.StringValue:
+			result[key] = v.ValueString()
+		case basetypes.NumberValue:
+			f, _ := v.ValueBigFloat().Float64()
+			result[key] = f
+		case basetypes.BoolValue:
+			result[key] = v.ValueBool()
+		default:
+			diags.AddError(
+				"Invalid Attributes",
+				fmt.Sprintf("attribute %q must be a string, number or bool, got %s", key, value.Type(nil)),
+			)
+		}
+	}
+	return result, diags
+}
+
+// flattenVectorStoreFileAttributes reflects the attributes reported by the API
+// onto the ones in state. Matching attributes keep their current value, so an
+// object in configuration is not replaced with an equivalent map. Attributes
+// that differ are stored as an object of the reported values.
+func flattenVectorStoreFileAttributes(current types.Dynamic, reported map[string]interface{}) types.Dynamic {
+	if current.IsNull() && len(reported) == 0 {
+		return current
+	}
+	if !current.IsNull() && !current.IsUnknown() {
+		if expanded, diags := expandVectorStoreFileAttributes(current); !diags.HasError() && reflect.DeepEqual(expanded, normalizeAttributes(reported)) {
+			return current
+		}
+	}
+
+	attrTypes := make(map[string]attr.Type, len(reported))
+	values := make(map[string]attr.Value, len(reported))
+	for key, value := range reported {
+		switch v := value.(type) {
+		case string:
+			attrTypes[key] = types.StringType
+			values[key] = types.StringValue(v)
+		case float64:
+			attrTypes[key] = types.NumberType
+			values[key] = types.NumberValue(new(big.Float).SetFloat64(v))
+		case bool:
+			attrTypes[key] = types.BoolType
+			values[key] = types.BoolValue(v)
+		}
+	}
+	return types.DynamicValue(types.ObjectValueMust(attrTypes, values))
+}
+
+// normalizeAttributes returns attributes as expandVectorStoreFileAttributes
+// would, with an empty map in place of nil
+func normalizeAttributes(attributes map[string]interface{}) map[string]interface{} {
+	if attributes == nil {
+		return map[string]interface{}{}
+	}
+	return attributes
+}
+
+// vectorStoreFileAttributeElements returns the elements of an object or map
+// held by a dynamic value
+func vectorStoreFileAttributeElements(attributes types.Dynamic) (map[string]attr.Value, bool) {
+	switch v := attributes.UnderlyingValue().(type) {
+	case basetypes.ObjectValue:
+		return v.Attributes(), true
+	case basetypes.MapValue:
+		return v.Elements(), true
+	default:
+		return nil, false
+	}
+}
+
+// sortedAttributeKeys returns the keys of elements in a stable order for diagnostics
+func sortedAttributeKeys(elements map[string]attr.Value) []string {
+	keys := make([]string, 0, len(elements))
+	for key := range elements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,225 @@
+package resources
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// attributesObject returns a dynamic object holding the given values
+func attributesObject(values map[string]attr.Value) types.Dynamic {
+	attrTypes := make(map[string]attr.Type, len(values))
+	for key, value := range values {
+		attrTypes[key] = value.Type(nil)
+	}
+	return types.DynamicValue(types.ObjectValueMust(attrTypes, values))
+}
+
+func TestValidateVectorStoreFileAttributes(t *testing.T) {
+	tooMany := make(map[string]attr.Value, maxVectorStoreFileAttributes+1)
+	for i := 0; i <= maxVectorStoreFileAttributes; i++ {
+		tooMany[string(rune('a'+i))] = types.BoolValue(true)
+	}
+
+	tests := []struct {
+		name       string
+		attributes types.Dynamic
+		wantErrors int
+	}{
+		{
+			name:       "null",
+			attributes: types.DynamicNull(),
+		},
+		{
+			name:       "unknown",
+			attributes: types.DynamicUnknown(),
+		},
+		{
+			name:       "unknown object",
+			attributes: types.DynamicValue(types.ObjectUnknown(map[string]attr.Type{"tenant": types.StringType})),
+		},
+		{
+			name: "object",
+			attributes: attributesObject(map[string]attr.Value{
+				"tenant":   types.StringValue("acme"),
+				"year":     types.NumberValue(big.NewFloat(2024)),
+				"archived": types.BoolValue(false),
+			}),
+		},
+		{
+			name: "map",
+			attributes: types.DynamicValue(types.MapValueMust(types.StringType, map[string]attr.Value{
+				"tenant": types.StringValue("acme"),
+			})),
+		},
+		{
+			name:       "unknown value",
+			attributes: attributesObject(map[string]attr.Value{"tenant": types.StringUnknown()}),
+		},
+		{
+			name:       "not an object",
+			attributes: types.DynamicValue(types.StringValue("acme")),
+			wantErrors: 1,
+		},
+		{
+			name:       "too many values",
+			attributes: attributesObject(tooMany),
+			wantErrors: 1,
+		},
+		{
+			name: "long key",
+			attributes: attributesObject(map[string]attr.Value{
+				strings.Repeat("k", maxVectorStoreFileAttributeKeyLength+1): types.BoolValue(true),
+			}),
+			wantErrors: 1,
+		},
+		{
+			name: "long value",
+			attributes: attributesObject(map[string]attr.Value{
+				"tenant": types.StringValue(strings.Repeat("v", maxVectorStoreFileAttributeValueLength+1)),
+			}),
+			wantErrors: 1,
+		},
+		{
+			name:       "null value",
+			attributes: attributesObject(map[string]attr.Value{"tenant": types.StringNull()}),
+			wantErrors: 1,
+		},
+		{
+			name: "nested value",
+			attributes: attributesObject(map[string]attr.Value{
+				"tags": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a")}),
+			}),
+			wantErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateVectorStoreFileAttributes(tt.attributes, path.Root("attributes"))
+			if got := diags.ErrorsCount(); got != tt.wantErrors {
+				t.Fatalf("got %d errors, want %d: %v", got, tt.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestExpandVectorStoreFileAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes types.Dynamic
+		want       map[string]interface{}
+		wantError  bool
+	}{
+		{
+			name:       "null",
+			attributes: types.DynamicNull(),
+		},
+		{
+			name: "object",
+			attributes: attributesObject(map[string]attr.Value{
+				"tenant":   types.StringValue("acme"),
+				"year":     types.NumberValue(big.NewFloat(2024)),
+				"archived": types.BoolValue(false),
+			}),
+			want: map[string]interface{}{"tenant": "acme", "year": float64(2024), "archived": false},
+		},
+		{
+			name:       "empty object",
+			attributes: attributesObject(map[string]attr.Value{}),
+			want:       map[string]interface{}{},
+		},
+		{
+			name:       "not an object",
+			attributes: types.DynamicValue(types.StringValue("acme")),
+			wantError:  true,
+		},
+		{
+			name: "nested value",
+			attributes: attributesObject(map[string]attr.Value{
+				"tags": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a")}),
+			}),
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := expandVectorStoreFileAttributes(tt.attributes)
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("got errors %v, want error %t", diags, tt.wantError)
+			}
+			if tt.wantError {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlattenVectorStoreFileAttributes(t *testing.T) {
+	configured := types.DynamicValue(types.MapValueMust(types.StringType, map[string]attr.Value{
+		"tenant": types.StringValue("acme"),
+	}))
+
+	tests := []struct {
+		name     string
+		current  types.Dynamic
+		reported map[string]interface{}
+		want     types.Dynamic
+	}{
+		{
+			name:    "unset and not reported",
+			current: types.DynamicNull(),
+			want:    types.DynamicNull(),
+		},
+		{
+			name:     "matching keeps the configured map",
+			current:  configured,
+			reported: map[string]interface{}{"tenant": "acme"},
+			want:     configured,
+		},
+		{
+			name:     "changed value",
+			current:  configured,
+			reported: map[string]interface{}{"tenant": "globex"},
+			want:     attributesObject(map[string]attr.Value{"tenant": types.StringValue("globex")}),
+		},
+		{
+			name:     "set outside of Terraform",
+			current:  types.DynamicNull(),
+			reported: map[string]interface{}{"year": float64(2024), "archived": true},
+			want: attributesObject(map[string]attr.Value{
+				"year":     types.NumberValue(big.NewFloat(2024)),
+				"archived": types.BoolValue(true),
+			}),
+		},
+		{
+			name:    "removed outside of Terraform",
+			current: configured,
+			want:    attributesObject(map[string]attr.Value{}),
+		},
+		{
+			name:     "empty object matches nothing reported",
+			current:  attributesObject(map[string]attr.Value{}),
+			reported: nil,
+			want:     attributesObject(map[string]attr.Value{}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := flattenVectorStoreFileAttributes(tt.current, tt.reported)
+			if !got.Equal(tt.want) {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
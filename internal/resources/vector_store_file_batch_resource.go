@@ -0,0 +1,562 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sashabaranov/go-openai"
+)
+
+// vectorStoreFileBatchMaxAttempts is how many times a file that cannot be
+// indexed is added during an apply before it is reported in failed_file_ids
+const vectorStoreFileBatchMaxAttempts = 3
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ resource.Resource = &VectorStoreFileBatchResource{}
+var _ resource.ResourceWithImportState = &VectorStoreFileBatchResource{}
+var _ resource.ResourceWithValidateConfig = &VectorStoreFileBatchResource{}
+
+func NewVectorStoreFileBatchResource() resource.Resource {
+	return &VectorStoreFileBatchResource{}
+}
+
+// VectorStoreFileBatchResource defines the resource implementation.
+type VectorStoreFileBatchResource struct {
+	client *client.Client
+}
+
+// VectorStoreFileBatchResourceModel describes the resource data model.
+type VectorStoreFileBatchResourceModel struct {
+	ID                types.String           `tfsdk:"id"`
+	VectorStoreID     types.String           `tfsdk:"vector_store_id"`
+	FileIDs           types.Set              `tfsdk:"file_ids"`
+	FailedFileIDs     types.Set              `tfsdk:"failed_file_ids"`
+	Attributes        types.Dynamic          `tfsdk:"attributes"`
+	CompletionTimeout types.String           `tfsdk:"completion_timeout"`
+	ChunkingStrategy  *ChunkingStrategyModel `tfsdk:"chunking_strategy"`
+}
+
+func (r *VectorStoreFileBatchResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vector_store_file_batch"
+}
+
+func (r *VectorStoreFileBatchResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a set of files within an OpenAI vector store, attached through file batches.",
+
+		Blocks: map[string]schema.Block{
+			"chunking_strategy": chunkingStrategySchemaBlock("How the files are split into chunks. Defaults to the API's `auto` strategy."),
+		},
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the first file batch created for this resource.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"vector_store_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the vector store to add the files to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the files to add to the vector store. Changes add or remove only the affected files.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"failed_file_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of files in file_ids that could not be indexed after several attempts. They are not added again unless they are removed from file_ids and added back.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"attributes": schema.DynamicAttribute{
+				MarkdownDescription: "Up to 16 string, number or bool values set on every file, for filtering file search results.",
+				Optional:            true,
+			},
+			"completion_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait for the files to be indexed. Defaults to 10m.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *VectorStoreFileBatchResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config VectorStoreFileBatchResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateChunkingStrategy(config.ChunkingStrategy, path.Root("chunking_strategy"))...)
+	resp.Diagnostics.Append(validateVectorStoreFileAttributes(config.Attributes, path.Root("attributes"))...)
+	resp.Diagnostics.Append(validateCompletionTimeout(config.CompletionTimeout)...)
+
+	if !config.FileIDs.IsNull() && !config.FileIDs.IsUnknown() && len(config.FileIDs.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("file_ids"),
+			"Invalid File IDs",
+			"file_ids must contain at least one file ID",
+		)
+	}
+}
+
+func (r *VectorStoreFileBatchResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *VectorStoreFileBatchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan VectorStoreFileBatchResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var fileIDs []string
+	resp.Diagnostics.Append(plan.FileIDs.ElementsAs(ctx, &fileIDs, false)...)
+	attributes, diags := expandVectorStoreFileAttributes(plan.Attributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Strings(fileIDs)
+
+	tflog.Debug(ctx, "Creating vector store file batch", map[string]interface{}{
+		"vector_store_id": plan.VectorStoreID.ValueString(),
+		"files":           len(fileIDs),
+	})
+
+	batchID, failed, diags := r.addFiles(ctx, &plan, fileIDs, attributes)
+	resp.Diagnostics.Append(diags...)
+	if batchID == "" {
+		// Nothing was attached, so there is nothing to save
+		return
+	}
+	plan.ID = types.StringValue(batchID)
+
+	failedFileIDs, diags := types.SetValueFrom(ctx, types.StringType, failed)
+	resp.Diagnostics.Append(diags...)
+	plan.FailedFileIDs = failedFileIDs
+
+	// Save into state even when a later batch failed, so the attached files
+	// are removed when the tainted resource is replaced
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *VectorStoreFileBatchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state VectorStoreFileBatchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vectorStoreID := state.VectorStoreID.ValueString()
+
+	// Imported resources only know their batch, so start from its files
+	var managed []string
+	if state.FileIDs.IsNull() {
+		batchFiles, err := r.client.ListVectorStoreFileBatchFiles(ctx, vectorStoreID, state.ID.ValueString(), "")
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Vector Store File Batch",
+				fmt.Sprintf("Unable to list the files in batch %s: %s", state.ID.ValueString(), r.client.HandleError(err)),
+			)
+			return
+		}
+		for _, file := range batchFiles {
+			managed = append(managed, file.ID)
+		}
+	} else {
+		resp.Diagnostics.Append(state.FileIDs.ElementsAs(ctx, &managed, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	files, err := r.client.ListVectorStoreFiles(ctx, vectorStoreID)
+	if err != nil {
+		var apiErr *openai.APIError
+		if errors.As(err, &apiErr) && apiErr.HTTPStatusCode == 404 {
+			tflog.Warn(ctx, "Vector store not found, removing file batch from state", map[string]interface{}{
+				"vector_store_id": vectorStoreID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Vector Store File Batch",
+			fmt.Sprintf("Unable to list vector store files: %s", r.client.HandleError(err)),
+		)
+		return
+	}
+
+	// Files that were cancelled or removed are dropped, so the next apply
+	// adds them again. Failed files were already retried when they were
+	// added, so they are kept and reported instead.
+	wanted := make(map[string]bool, len(managed))
+	for _, id := range managed {
+		wanted[id] = true
+	}
+	var present []string
+	failed := []string{}
+	var attributesDrifted, chunkingDrifted bool
+	for _, file := range files {
+		if !wanted[file.ID] || file.Status == "cancelled" {
+			continue
+		}
+		present = append(present, file.ID)
+		if file.Status == "failed" {
+			// A failed file has no chunks, so it cannot have drifted
+			failed = append(failed, file.ID)
+			continue
+		}
+
+		// Any file that differs is enough to show the drift and reapply
+		if !attributesDrifted {
+			attributes := flattenVectorStoreFileAttributes(state.Attributes, file.Attributes)
+			if !attributes.Equal(state.Attributes) {
+				state.Attributes = attributes
+				attributesDrifted = true
+			}
+		}
+		if !chunkingDrifted {
+			strategy := flattenChunkingStrategy(state.ChunkingStrategy, file.ChunkingStrategy)
+			if !chunkingStrategyEqual(strategy, state.ChunkingStrategy) {
+				state.ChunkingStrategy = strategy
+				chunkingDrifted = true
+			}
+		}
+	}
+	sort.Strings(present)
+	sort.Strings(failed)
+
+	fileIDs, diags := types.SetValueFrom(ctx, types.StringType, present)
+	resp.Diagnostics.Append(diags...)
+	failedFileIDs, diags := types.SetValueFrom(ctx, types.StringType, failed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.FileIDs = fileIDs
+	state.FailedFileIDs = failedFileIDs
+
+	// Save updated state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *VectorStoreFileBatchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state VectorStoreFileBatchResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planned, current, previouslyFailed []string
+	resp.Diagnostics.Append(plan.FileIDs.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(state.FileIDs.ElementsAs(ctx, &current, false)...)
+	if !state.FailedFileIDs.IsNull() {
+		resp.Diagnostics.Append(state.FailedFileIDs.ElementsAs(ctx, &previouslyFailed, false)...)
+	}
+	attributes, diags := expandVectorStoreFileAttributes(plan.Attributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	toAdd, toKeep, toRemove := diffFileIDs(current, planned)
+	vectorStoreID := plan.VectorStoreID.ValueString()
+
+	tflog.Debug(ctx, "Updating vector store file batch", map[string]interface{}{
+		"vector_store_id": vectorStoreID,
+		"add":             len(toAdd),
+		"remove":          len(toRemove),
+	})
+
+	resp.Diagnostics.Append(r.removeFiles(ctx, vectorStoreID, toRemove)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Files that stay only need their attributes replaced
+	if !plan.Attributes.Equal(state.Attributes) {
+		for _, fileID := range toKeep {
+			if _, err := r.client.UpdateVectorStoreFileAttributes(ctx, vectorStoreID, fileID, attributes); err != nil {
+				resp.Diagnostics.AddError(
+					"Error Updating Vector Store File",
+					fmt.Sprintf("Unable to update the attributes of file %s: %s", fileID, r.client.HandleError(err)),
+				)
+				return
+			}
+		}
+	}
+
+	if len(toAdd) > 0 {
+		toAdd, diags = r.prepareReaddedFiles(ctx, vectorStoreID, toAdd, attributes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	// Files that stay keep their failures, and new ones are added to them
+	_, kept, _ := diffFileIDs(previouslyFailed, toKeep)
+	failed := append([]string{}, kept...)
+	if len(toAdd) > 0 {
+		var added []string
+		_, added, diags = r.addFiles(ctx, &plan, toAdd, attributes)
+		resp.Diagnostics.Append(diags...)
+		failed = append(failed, added...)
+	}
+	sort.Strings(failed)
+
+	failedFileIDs, diags := types.SetValueFrom(ctx, types.StringType, failed)
+	resp.Diagnostics.Append(diags...)
+	plan.FailedFileIDs = failedFileIDs
+
+	// Save updated state
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *VectorStoreFileBatchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state VectorStoreFileBatchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var fileIDs []string
+	resp.Diagnostics.Append(state.FileIDs.ElementsAs(ctx, &fileIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.removeFiles(ctx, state.VectorStoreID.ValueString(), fileIDs)...)
+}
+
+func (r *VectorStoreFileBatchResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Expected import ID format: vector_store_id:batch_id
+	idParts := strings.Split(req.ID, ":")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			`The import ID must be in the format "vector_store_id:batch_id"`,
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vector_store_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+}
+
+// addFiles attaches files in batches of up to client.VectorStoreFileBatchMaxFiles
+// and waits for each batch to finish. Files that cannot be indexed are
+// removed and added again, up to vectorStoreFileBatchMaxAttempts times. It
+// returns the ID of the first batch created, which is empty if none could be
+// created, and the files that still failed.
+func (r *VectorStoreFileBatchResource) addFiles(ctx context.Context, plan *VectorStoreFileBatchResourceModel, fileIDs []string, attributes map[string]interface{}) (string, []string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	timeout, err := completionTimeout(plan.CompletionTimeout)
+	if err != nil {
+		diags.AddError(
+			"Invalid Completion Timeout",
+			fmt.Sprintf("Unable to parse completion timeout: %s", err),
+		)
+		return "", nil, diags
+	}
+	deadline := time.Now().Add(timeout)
+
+	vectorStoreID := plan.VectorStoreID.ValueString()
+	firstBatchID := ""
+	pending := fileIDs
+	var failed []client.VectorStoreFile
+	for attempt := 1; ; attempt++ {
+		failed = nil
+		for start := 0; start < len(pending); start += client.VectorStoreFileBatchMaxFiles {
+			end := start + client.VectorStoreFileBatchMaxFiles
+			if end > len(pending) {
+				end = len(pending)
+			}
+
+			batch, err := r.client.CreateVectorStoreFileBatch(ctx, vectorStoreID, client.CreateVectorStoreFileBatchRequest{
+				FileIDs:          pending[start:end],
+				ChunkingStrategy: expandChunkingStrategy(plan.ChunkingStrategy),
+				Attributes:       attributes,
+			})
+			if err != nil {
+				diags.AddError(
+					"Error Creating Vector Store File Batch",
+					fmt.Sprintf("Unable to add files to vector store: %s", r.client.HandleError(err)),
+				)
+				return firstBatchID, nil, diags
+			}
+			if firstBatchID == "" {
+				firstBatchID = batch.ID
+			}
+
+			batchFailed, d := waitForVectorStoreFileBatch(ctx, r.client, batch, deadline, timeout)
+			diags.Append(d...)
+			if diags.HasError() {
+				return firstBatchID, nil, diags
+			}
+			failed = append(failed, batchFailed...)
+		}
+
+		if len(failed) == 0 || attempt == vectorStoreFileBatchMaxAttempts {
+			break
+		}
+
+		// Indexing failures are often transient, so the failed files are
+		// removed and added again
+		pending = nil
+		for _, file := range failed {
+			pending = append(pending, file.ID)
+		}
+		tflog.Debug(ctx, "Adding failed vector store files again", map[string]interface{}{
+			"vector_store_id": vectorStoreID,
+			"files":           len(pending),
+			"attempt":         attempt + 1,
+		})
+		diags.Append(r.removeFiles(ctx, vectorStoreID, pending)...)
+		if diags.HasError() {
+			return firstBatchID, nil, diags
+		}
+	}
+
+	failedIDs := []string{}
+	for _, file := range failed {
+		detail := fmt.Sprintf("File %s could not be indexed in vector store %s after %d attempts", file.ID, vectorStoreID, vectorStoreFileBatchMaxAttempts)
+		if file.LastError != nil {
+			detail += fmt.Sprintf(": %s: %s", file.LastError.Code, file.LastError.Message)
+		}
+		diags.AddWarning("Vector Store File Failed", detail+". It is listed in failed_file_ids and is not added again unless it is removed from file_ids and added back.")
+		failedIDs = append(failedIDs, file.ID)
+	}
+	sort.Strings(failedIDs)
+
+	return firstBatchID, failedIDs, diags
+}
+
+// prepareReaddedFiles handles files that are being added but are already in
+// the vector store. Failed files are removed so they can be indexed again,
+// and files that are still attached are adopted with the planned attributes.
+// It returns the files that still need to be added.
+func (r *VectorStoreFileBatchResource) prepareReaddedFiles(ctx context.Context, vectorStoreID string, fileIDs []string, attributes map[string]interface{}) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	files, err := r.client.ListVectorStoreFiles(ctx, vectorStoreID)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Vector Store File Batch",
+			fmt.Sprintf("Unable to list vector store files: %s", r.client.HandleError(err)),
+		)
+		return nil, diags
+	}
+	existing := make(map[string]string, len(files))
+	for _, file := range files {
+		existing[file.ID] = file.Status
+	}
+
+	var remaining, failed []string
+	for _, fileID := range fileIDs {
+		switch existing[fileID] {
+		case "":
+			remaining = append(remaining, fileID)
+		case "failed", "cancelled":
+			failed = append(failed, fileID)
+			remaining = append(remaining, fileID)
+		default:
+			if _, err := r.client.UpdateVectorStoreFileAttributes(ctx, vectorStoreID, fileID, attributes); err != nil {
+				diags.AddError(
+					"Error Updating Vector Store File",
+					fmt.Sprintf("Unable to update the attributes of file %s: %s", fileID, r.client.HandleError(err)),
+				)
+				return nil, diags
+			}
+		}
+	}
+
+	diags.Append(r.removeFiles(ctx, vectorStoreID, failed)...)
+	return remaining, diags
+}
+
+// removeFiles detaches files from the vector store. Files that are already
+// gone are skipped.
+func (r *VectorStoreFileBatchResource) removeFiles(ctx context.Context, vectorStoreID string, fileIDs []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, fileID := range fileIDs {
+		err := r.client.OpenAI.DeleteVectorStoreFile(ctx, vectorStoreID, fileID)
+		if err != nil {
+			if apiErr, ok := err.(*openai.APIError); ok && apiErr.HTTPStatusCode == 404 {
+				continue
+			}
+			diags.AddError(
+				"Error Deleting Vector Store File",
+				fmt.Sprintf("Unable to remove file %s from vector store: %s", fileID, r.client.HandleError(err)),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// diffFileIDs splits the planned files into those to add and those to keep,
+// and returns the current files that are no longer planned
+func diffFileIDs(current []string, planned []string) (toAdd []string, toKeep []string, toRemove []string) {
+	inCurrent := make(map[string]bool, len(current))
+	for _, id := range current {
+		inCurrent[id] = true
+	}
+	inPlanned := make(map[string]bool, len(planned))
+	for _, id := range planned {
+		inPlanned[id] = true
+		if inCurrent[id] {
+			toKeep = append(toKeep, id)
+		} else {
+			toAdd = append(toAdd, id)
+		}
+	}
+	for _, id := range current {
+		if !inPlanned[id] {
+			toRemove = append(toRemove, id)
+		}
+	}
+
+	sort.Strings(toAdd)
+	sort.Strings(toKeep)
+	sort.Strings(toRemove)
+	return toAdd, toKeep, toRemove
+}
@@ -0,0 +1,60 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffFileIDs(t *testing.T) {
+	tests := []struct {
+		name         string
+		current      []string
+		planned      []string
+		wantToAdd    []string
+		wantToKeep   []string
+		wantToRemove []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:      "create",
+			planned:   []string{"file-b", "file-a"},
+			wantToAdd: []string{"file-a", "file-b"},
+		},
+		{
+			name:       "unchanged",
+			current:    []string{"file-a", "file-b"},
+			planned:    []string{"file-b", "file-a"},
+			wantToKeep: []string{"file-a", "file-b"},
+		},
+		{
+			name:         "add and remove",
+			current:      []string{"file-c", "file-a"},
+			planned:      []string{"file-b", "file-a"},
+			wantToAdd:    []string{"file-b"},
+			wantToKeep:   []string{"file-a"},
+			wantToRemove: []string{"file-c"},
+		},
+		{
+			name:         "remove all",
+			current:      []string{"file-b", "file-a"},
+			wantToRemove: []string{"file-a", "file-b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toAdd, toKeep, toRemove := diffFileIDs(tt.current, tt.planned)
+			if !reflect.DeepEqual(toAdd, tt.wantToAdd) {
+				t.Errorf("got toAdd %v, want %v", toAdd, tt.wantToAdd)
+			}
+			if !reflect.DeepEqual(toKeep, tt.wantToKeep) {
+				t.Errorf("got toKeep %v, want %v", toKeep, tt.wantToKeep)
+			}
+			if !reflect.DeepEqual(toRemove, tt.wantToRemove) {
+				t.Errorf("got toRemove %v, want %v", toRemove, tt.wantToRemove)
+			}
+		})
+	}
+}
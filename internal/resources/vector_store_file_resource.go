@@ -6,20 +6,20 @@ import (
 	"strings"
 
 	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/sashabaranov/go-openai"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &VectorStoreFileResource{}
 var _ resource.ResourceWithImportState = &VectorStoreFileResource{}
+var _ resource.ResourceWithValidateConfig = &VectorStoreFileResource{}
 
 func NewVectorStoreFileResource() resource.Resource {
 	return &VectorStoreFileResource{}
@@ -32,12 +32,16 @@ type VectorStoreFileResource struct {
 
 // VectorStoreFileResourceModel describes the resource data model.
 type VectorStoreFileResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	VectorStoreID types.String `tfsdk:"vector_store_id"`
-	FileID        types.String `tfsdk:"file_id"`
-	CreatedAt     types.Int64  `tfsdk:"created_at"`
-	UsageBytes    types.Int64  `tfsdk:"usage_bytes"`
-	Status        types.String `tfsdk:"status"`
+	ID                types.String           `tfsdk:"id"`
+	VectorStoreID     types.String           `tfsdk:"vector_store_id"`
+	FileID            types.String           `tfsdk:"file_id"`
+	CreatedAt         types.Int64            `tfsdk:"created_at"`
+	UsageBytes        types.Int64            `tfsdk:"usage_bytes"`
+	Status            types.String           `tfsdk:"status"`
+	ChunkingStrategy  *ChunkingStrategyModel `tfsdk:"chunking_strategy"`
+	Attributes        types.Dynamic          `tfsdk:"attributes"`
+	WaitForCompletion types.Bool             `tfsdk:"wait_for_completion"`
+	CompletionTimeout types.String           `tfsdk:"completion_timeout"`
 }
 
 func (r *VectorStoreFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -48,6 +52,10 @@ func (r *VectorStoreFileResource) Schema(_ context.Context, _ resource.SchemaReq
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a file within an OpenAI vector store.",
 
+		Blocks: map[string]schema.Block{
+			"chunking_strategy": chunkingStrategySchemaBlock("How the file is split into chunks. Defaults to the API's `auto` strategy."),
+		},
+
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The unique identifier for this vector store file.",
@@ -64,14 +72,11 @@ func (r *VectorStoreFileResource) Schema(_ context.Context, _ resource.SchemaReq
 				},
 			},
 			"file_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the file to add to the vector store. **Note:** CSV files are not supported.",
+				MarkdownDescription: "The ID of the file to add to the vector store. The file must be of a type file search can index, otherwise indexing fails with an `unsupported_file` error.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
-				Validators: []validator.String{
-					fileTypeValidator{},
-				},
 			},
 			"created_at": schema.Int64Attribute{
 				MarkdownDescription: "The timestamp when the file was added to the vector store.",
@@ -85,31 +90,32 @@ func (r *VectorStoreFileResource) Schema(_ context.Context, _ resource.SchemaReq
 				MarkdownDescription: "The current status of the file in the vector store.",
 				Computed:            true,
 			},
+			"attributes": schema.DynamicAttribute{
+				MarkdownDescription: "Up to 16 string, number or bool values for filtering file search results, such as `{ tenant = \"acme\" }`. Can be updated in place.",
+				Optional:            true,
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait until the file has been indexed before marking the resource as created. Indexing failures fail the apply with the file's last_error. Defaults to false.",
+				Optional:            true,
+			},
+			"completion_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait for indexing when wait_for_completion is true. Defaults to 10m.",
+				Optional:            true,
+			},
 		},
 	}
 }
 
-// fileTypeValidator validates that the file is not a CSV file
-type fileTypeValidator struct{}
-
-func (v fileTypeValidator) Description(_ context.Context) string {
-	return "file must not be a CSV file"
-}
-
-func (v fileTypeValidator) MarkdownDescription(ctx context.Context) string {
-	return v.Description(ctx)
-}
-
-func (v fileTypeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
-	// For now, we can only validate by looking at the file_id suffix
-	// In the future, we may want to make an API call to check the file's actual content type
-	if req.ConfigValue.ValueString() != "" && strings.HasSuffix(strings.ToLower(req.ConfigValue.ValueString()), ".csv") {
-		resp.Diagnostics.AddAttributeError(
-			req.Path,
-			"Invalid File Type",
-			"CSV files are not supported for vector store retrieval. See https://platform.openai.com/docs/assistants/tools/file-search/supported-files",
-		)
+func (r *VectorStoreFileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config VectorStoreFileResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	resp.Diagnostics.Append(validateChunkingStrategy(config.ChunkingStrategy, path.Root("chunking_strategy"))...)
+	resp.Diagnostics.Append(validateVectorStoreFileAttributes(config.Attributes, path.Root("attributes"))...)
+	resp.Diagnostics.Append(validateCompletionTimeout(config.CompletionTimeout)...)
 }
 
 func (r *VectorStoreFileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -137,13 +143,21 @@ func (r *VectorStoreFileResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	attributes, diags := expandVectorStoreFileAttributes(plan.Attributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, "Creating vector store file", map[string]interface{}{
 		"vector_store_id": plan.VectorStoreID.ValueString(),
 		"file_id":         plan.FileID.ValueString(),
 	})
 
-	result, err := r.client.OpenAI.CreateVectorStoreFile(ctx, plan.VectorStoreID.ValueString(), openai.VectorStoreFileRequest{
-		FileID: plan.FileID.ValueString(),
+	result, err := r.client.CreateVectorStoreFile(ctx, plan.VectorStoreID.ValueString(), client.CreateVectorStoreFileRequest{
+		FileID:           plan.FileID.ValueString(),
+		ChunkingStrategy: expandChunkingStrategy(plan.ChunkingStrategy),
+		Attributes:       attributes,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -153,15 +167,31 @@ func (r *VectorStoreFileResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	// Wait for indexing so dependent runs never search an incomplete index.
+	// The file is saved either way so a failed file is replaced.
+	if plan.WaitForCompletion.ValueBool() {
+		timeout, err := completionTimeout(plan.CompletionTimeout)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Completion Timeout",
+				fmt.Sprintf("Unable to parse completion timeout: %s", err),
+			)
+		} else {
+			var waitDiags diag.Diagnostics
+			result, waitDiags = waitForVectorStoreFile(ctx, r.client, result, timeout)
+			resp.Diagnostics.Append(waitDiags...)
+		}
+	}
+
 	// Map response to model
 	plan.ID = types.StringValue(result.ID)
 	plan.CreatedAt = types.Int64Value(result.CreatedAt)
 	plan.Status = types.StringValue(result.Status)
-	plan.UsageBytes = types.Int64Value(int64(result.UsageBytes))
+	plan.UsageBytes = types.Int64Value(result.UsageBytes)
+	plan.ChunkingStrategy = flattenChunkingStrategy(plan.ChunkingStrategy, result.ChunkingStrategy)
 
 	// Save into state
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
 func (r *VectorStoreFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -172,7 +202,7 @@ func (r *VectorStoreFileResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	result, err := r.client.OpenAI.RetrieveVectorStoreFile(ctx, state.VectorStoreID.ValueString(), state.ID.ValueString())
+	result, err := r.client.GetVectorStoreFile(ctx, state.VectorStoreID.ValueString(), state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Vector Store File",
@@ -184,7 +214,9 @@ func (r *VectorStoreFileResource) Read(ctx context.Context, req resource.ReadReq
 	// Update state
 	state.CreatedAt = types.Int64Value(result.CreatedAt)
 	state.Status = types.StringValue(result.Status)
-	state.UsageBytes = types.Int64Value(int64(result.UsageBytes))
+	state.UsageBytes = types.Int64Value(result.UsageBytes)
+	state.ChunkingStrategy = flattenChunkingStrategy(state.ChunkingStrategy, result.ChunkingStrategy)
+	state.Attributes = flattenVectorStoreFileAttributes(state.Attributes, result.Attributes)
 
 	// Save updated state
 	diags = resp.State.Set(ctx, &state)
@@ -192,7 +224,7 @@ func (r *VectorStoreFileResource) Read(ctx context.Context, req resource.ReadReq
 }
 
 func (r *VectorStoreFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Files in vector stores can't be updated, they need to be replaced
+	// Only attributes can be updated, other changes replace the file
 	var plan VectorStoreFileResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -207,20 +239,44 @@ func (r *VectorStoreFileResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	// Since the file can't be updated, we just read the current state
-	result, err := r.client.OpenAI.RetrieveVectorStoreFile(ctx, state.VectorStoreID.ValueString(), state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Vector Store File",
-			fmt.Sprintf("Unable to read vector store file: %s", r.client.HandleError(err)),
-		)
-		return
+	var result *client.VectorStoreFile
+	var err error
+	if plan.Attributes.Equal(state.Attributes) {
+		result, err = r.client.GetVectorStoreFile(ctx, state.VectorStoreID.ValueString(), state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Vector Store File",
+				fmt.Sprintf("Unable to read vector store file: %s", r.client.HandleError(err)),
+			)
+			return
+		}
+	} else {
+		attributes, diags := expandVectorStoreFileAttributes(plan.Attributes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		tflog.Debug(ctx, "Updating vector store file attributes", map[string]interface{}{
+			"vector_store_id": state.VectorStoreID.ValueString(),
+			"file_id":         state.ID.ValueString(),
+		})
+
+		result, err = r.client.UpdateVectorStoreFileAttributes(ctx, state.VectorStoreID.ValueString(), state.ID.ValueString(), attributes)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Vector Store File",
+				fmt.Sprintf("Unable to update vector store file attributes: %s", r.client.HandleError(err)),
+			)
+			return
+		}
 	}
 
 	// Update state
 	plan.CreatedAt = types.Int64Value(result.CreatedAt)
 	plan.Status = types.StringValue(result.Status)
-	plan.UsageBytes = types.Int64Value(int64(result.UsageBytes))
+	plan.UsageBytes = types.Int64Value(result.UsageBytes)
+	plan.ChunkingStrategy = flattenChunkingStrategy(plan.ChunkingStrategy, result.ChunkingStrategy)
 
 	// Save updated state
 	diags = resp.State.Set(ctx, &plan)
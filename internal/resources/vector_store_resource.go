@@ -5,10 +5,12 @@ import (
 	"fmt"
 
 	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -18,6 +20,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces
 var _ resource.Resource = &VectorStoreResource{}
 var _ resource.ResourceWithImportState = &VectorStoreResource{}
+var _ resource.ResourceWithValidateConfig = &VectorStoreResource{}
 
 func NewVectorStoreResource() resource.Resource {
 	return &VectorStoreResource{}
@@ -40,7 +43,11 @@ type VectorStoreResourceModel struct {
 		Days   types.Int64  `tfsdk:"days"`
 		Anchor types.String `tfsdk:"anchor"`
 	} `tfsdk:"expires_after"`
-	Metadata map[string]string `tfsdk:"metadata"`
+	Metadata          map[string]string      `tfsdk:"metadata"`
+	FileIDs           types.Set              `tfsdk:"file_ids"`
+	ChunkingStrategy  *ChunkingStrategyModel `tfsdk:"chunking_strategy"`
+	WaitForCompletion types.Bool             `tfsdk:"wait_for_completion"`
+	CompletionTimeout types.String           `tfsdk:"completion_timeout"`
 }
 
 func (r *VectorStoreResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -66,9 +73,18 @@ func (r *VectorStoreResource) Schema(_ context.Context, _ resource.SchemaRequest
 					},
 				},
 			},
+			"chunking_strategy": chunkingStrategySchemaBlock("How the files in `file_ids` are split into chunks. Defaults to the API's `auto` strategy."),
 		},
 
 		Attributes: map[string]schema.Attribute{
+			"file_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "IDs of files to add to the vector store when it is created. Changing this forces a new vector store.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
 			"metadata": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
@@ -101,10 +117,38 @@ func (r *VectorStoreResource) Schema(_ context.Context, _ resource.SchemaRequest
 				MarkdownDescription: "The timestamp when the vector store will expire.",
 				Computed:            true,
 			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait until none of the vector store's files are being indexed before marking the resource as created. Defaults to false.",
+				Optional:            true,
+			},
+			"completion_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait for indexing when wait_for_completion is true. Defaults to 10m.",
+				Optional:            true,
+			},
 		},
 	}
 }
 
+func (r *VectorStoreResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config VectorStoreResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateChunkingStrategy(config.ChunkingStrategy, path.Root("chunking_strategy"))...)
+	resp.Diagnostics.Append(validateCompletionTimeout(config.CompletionTimeout)...)
+
+	// The strategy only applies to the files added with the vector store
+	if config.ChunkingStrategy != nil && config.FileIDs.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("chunking_strategy"),
+			"Chunking Strategy Has No Effect",
+			"chunking_strategy only applies to the files in file_ids. Set chunking_strategy on each openai_vector_store_file instead.",
+		)
+	}
+}
+
 func (r *VectorStoreResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -136,10 +180,20 @@ func (r *VectorStoreResource) Create(ctx context.Context, req resource.CreateReq
 		metadata[k] = v
 	}
 
+	var fileIDs []string
+	if !plan.FileIDs.IsNull() {
+		resp.Diagnostics.Append(plan.FileIDs.ElementsAs(ctx, &fileIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Build the request
-	createReq := openai.VectorStoreRequest{
-		Name:     plan.Name.ValueString(),
-		Metadata: metadata,
+	createReq := client.CreateVectorStoreRequest{
+		Name:             plan.Name.ValueString(),
+		FileIDs:          fileIDs,
+		ChunkingStrategy: expandChunkingStrategy(plan.ChunkingStrategy),
+		Metadata:         metadata,
 	}
 
 	// Add expiration if configured
@@ -151,10 +205,11 @@ func (r *VectorStoreResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	tflog.Debug(ctx, "Creating vector store", map[string]interface{}{
-		"name": createReq.Name,
+		"name":  createReq.Name,
+		"files": len(fileIDs),
 	})
 
-	result, err := r.client.OpenAI.CreateVectorStore(ctx, createReq)
+	result, err := r.client.CreateVectorStore(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Vector Store",
@@ -163,6 +218,22 @@ func (r *VectorStoreResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	// Wait for the initial files to be indexed. The vector store is saved
+	// either way so it is not orphaned.
+	if plan.WaitForCompletion.ValueBool() {
+		timeout, err := completionTimeout(plan.CompletionTimeout)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Completion Timeout",
+				fmt.Sprintf("Unable to parse completion timeout: %s", err),
+			)
+		} else {
+			var waitDiags diag.Diagnostics
+			result, waitDiags = waitForVectorStore(ctx, r.client, result, timeout)
+			resp.Diagnostics.Append(waitDiags...)
+		}
+	}
+
 	// Map response to model
 	plan.ID = types.StringValue(result.ID)
 	plan.CreatedAt = types.Int64Value(result.CreatedAt)
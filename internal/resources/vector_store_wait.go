@@ -0,0 +1,227 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/darnold/terraform-provider-openai/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/sashabaranov/go-openai"
+)
+
+const (
+	// defaultVectorStoreCompletionTimeout bounds wait_for_completion when completion_timeout is not set
+	defaultVectorStoreCompletionTimeout = 10 * time.Minute
+	// vectorStorePollInterval is how often indexing progress is checked while waiting
+	vectorStorePollInterval = 2 * time.Second
+)
+
+// validateCompletionTimeout checks that completion_timeout is a positive duration
+func validateCompletionTimeout(timeout types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if timeout.IsNull() || timeout.IsUnknown() {
+		return diags
+	}
+
+	if d, err := time.ParseDuration(timeout.ValueString()); err != nil || d <= 0 {
+		diags.AddAttributeError(
+			path.Root("completion_timeout"),
+			"Invalid Completion Timeout",
+			fmt.Sprintf("completion_timeout must be a positive duration such as \"10m\", got %q", timeout.ValueString()),
+		)
+	}
+	return diags
+}
+
+// completionTimeout returns the configured completion_timeout or the default
+func completionTimeout(timeout types.String) (time.Duration, error) {
+	if timeout.IsNull() {
+		return defaultVectorStoreCompletionTimeout, nil
+	}
+	return time.ParseDuration(timeout.ValueString())
+}
+
+// waitForVectorStoreFile polls a vector store file until it leaves
+// in_progress. It returns the last file read, so the caller can save its
+// status even when waiting fails.
+func waitForVectorStoreFile(ctx context.Context, c *client.Client, file *client.VectorStoreFile, timeout time.Duration) (*client.VectorStoreFile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	deadline := time.Now().Add(timeout)
+
+	for file.Status == "in_progress" {
+		if time.Now().After(deadline) {
+			diags.AddError(
+				"Vector Store File Indexing Timeout",
+				fmt.Sprintf("File %s was still %s in vector store %s after %s", file.ID, file.Status, file.VectorStoreID, timeout),
+			)
+			return file, diags
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"Vector Store File Indexing Interrupted",
+				fmt.Sprintf("Stopped waiting for file %s while it was %s: %s", file.ID, file.Status, ctx.Err()),
+			)
+			return file, diags
+		case <-time.After(vectorStorePollInterval):
+		}
+
+		next, err := c.GetVectorStoreFile(ctx, file.VectorStoreID, file.ID)
+		if err != nil {
+			diags.AddError(
+				"Error Reading Vector Store File",
+				fmt.Sprintf("Unable to read vector store file status: %s", c.HandleError(err)),
+			)
+			return file, diags
+		}
+		file = next
+
+		tflog.Debug(ctx, "Waiting for vector store file indexing", map[string]interface{}{
+			"vector_store_id": file.VectorStoreID,
+			"file_id":         file.ID,
+			"status":          file.Status,
+		})
+	}
+
+	switch file.Status {
+	case "completed":
+	case "failed":
+		detail := fmt.Sprintf("File %s could not be indexed in vector store %s", file.ID, file.VectorStoreID)
+		if file.LastError != nil {
+			detail += fmt.Sprintf(": %s: %s", file.LastError.Code, file.LastError.Message)
+		}
+		diags.AddError("Vector Store File Indexing Failed", detail)
+	default:
+		diags.AddError(
+			"Vector Store File Indexing Failed",
+			fmt.Sprintf("File %s finished with status %s in vector store %s", file.ID, file.Status, file.VectorStoreID),
+		)
+	}
+
+	return file, diags
+}
+
+// waitForVectorStore polls a vector store until none of its files are
+// in_progress. It returns the last vector store read, so the caller can save
+// its status even when waiting fails.
+func waitForVectorStore(ctx context.Context, c *client.Client, store *openai.VectorStore, timeout time.Duration) (*openai.VectorStore, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	deadline := time.Now().Add(timeout)
+
+	for store.FileCounts.InProgress > 0 {
+		if time.Now().After(deadline) {
+			diags.AddError(
+				"Vector Store Indexing Timeout",
+				fmt.Sprintf("Vector store %s still had %d of %d files in progress after %s", store.ID, store.FileCounts.InProgress, store.FileCounts.Total, timeout),
+			)
+			return store, diags
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"Vector Store Indexing Interrupted",
+				fmt.Sprintf("Stopped waiting for vector store %s with %d files in progress: %s", store.ID, store.FileCounts.InProgress, ctx.Err()),
+			)
+			return store, diags
+		case <-time.After(vectorStorePollInterval):
+		}
+
+		next, err := c.OpenAI.RetrieveVectorStore(ctx, store.ID)
+		if err != nil {
+			diags.AddError(
+				"Error Reading Vector Store",
+				fmt.Sprintf("Unable to read vector store status: %s", c.HandleError(err)),
+			)
+			return store, diags
+		}
+		store = &next
+
+		tflog.Debug(ctx, "Waiting for vector store indexing", map[string]interface{}{
+			"vector_store_id": store.ID,
+			"in_progress":     store.FileCounts.InProgress,
+			"completed":       store.FileCounts.Completed,
+		})
+	}
+
+	// The vector store is still usable without the failed files, so they are
+	// only reported
+	if store.FileCounts.Failed > 0 {
+		diags.AddWarning(
+			"Vector Store Files Failed",
+			fmt.Sprintf("%d of %d files in vector store %s could not be indexed.", store.FileCounts.Failed, store.FileCounts.Total, store.ID),
+		)
+	}
+
+	return store, diags
+}
+
+// waitForVectorStoreFileBatch polls a file batch until it leaves in_progress
+// or the deadline passes, then returns the files in the batch that could not
+// be indexed
+func waitForVectorStoreFileBatch(ctx context.Context, c *client.Client, batch *client.VectorStoreFileBatch, deadline time.Time, timeout time.Duration) ([]client.VectorStoreFile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	for batch.Status == "in_progress" {
+		if time.Now().After(deadline) {
+			diags.AddError(
+				"Vector Store File Batch Timeout",
+				fmt.Sprintf("File batch %s still had %d of %d files in progress after %s", batch.ID, batch.FileCounts.InProgress, batch.FileCounts.Total, timeout),
+			)
+			return nil, diags
+		}
+
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"Vector Store File Batch Interrupted",
+				fmt.Sprintf("Stopped waiting for file batch %s with %d files in progress: %s", batch.ID, batch.FileCounts.InProgress, ctx.Err()),
+			)
+			return nil, diags
+		case <-time.After(vectorStorePollInterval):
+		}
+
+		next, err := c.GetVectorStoreFileBatch(ctx, batch.VectorStoreID, batch.ID)
+		if err != nil {
+			diags.AddError(
+				"Error Reading Vector Store File Batch",
+				fmt.Sprintf("Unable to read file batch status: %s", c.HandleError(err)),
+			)
+			return nil, diags
+		}
+		batch = next
+
+		tflog.Debug(ctx, "Waiting for vector store file batch", map[string]interface{}{
+			"vector_store_id": batch.VectorStoreID,
+			"batch_id":        batch.ID,
+			"in_progress":     batch.FileCounts.InProgress,
+			"completed":       batch.FileCounts.Completed,
+		})
+	}
+
+	if batch.Status != "completed" {
+		diags.AddError(
+			"Vector Store File Batch Failed",
+			fmt.Sprintf("File batch %s finished with status %s", batch.ID, batch.Status),
+		)
+	}
+
+	if batch.FileCounts.Failed == 0 {
+		return nil, diags
+	}
+
+	failed, err := c.ListVectorStoreFileBatchFiles(ctx, batch.VectorStoreID, batch.ID, "failed")
+	if err != nil {
+		diags.AddError(
+			"Error Listing Failed Vector Store Files",
+			fmt.Sprintf("%d files in batch %s could not be indexed, and the failures could not be listed: %s", batch.FileCounts.Failed, batch.ID, c.HandleError(err)),
+		)
+		return nil, diags
+	}
+	return failed, diags
+}